@@ -30,23 +30,54 @@ Usage:
 		node-instances    Handle a deployment's node-instances
 		nodes             Handle a deployment's nodes
 		plugins           Handle plugins on the manager
+		snapshots         Handle manager snapshots
 		status            Show manager status
 		kubernetes        Additional kubernetes operations
 		version           Show client version.
 		tenants           Show tenants on the manager
+		profiles          Handle connection profiles
 
 Common parameters for commands required network communication:
 
+	-profile string
+		Profile from config file or CFY_PROFILE in env
 	-debug
 		Manager debug or CFY_DEBUG in env
 	-host string
 		Manager host name or CFY_HOST in env (default "localhost")
 	-password string
 		Manager user password or CFY_PASSWORD in env (default "secret")
+	-retry-attempts int
+		Attempts count for requests failed by transient errors or CFY_RETRY_ATTEMPTS in env (default 1)
 	-tenant string
 		Manager tenant or CFY_TENANT in env (default "default_tenant")
 	-user string
 		Manager user name or CFY_USER in env (default "admin")
+	-token string
+		Manager api token, used instead of user/password, or CFY_TOKEN in env
+	-session-token
+		Get session token by user/password and use it for requests or CFY_SESSION_TOKEN in env
+	-output string
+		Output format: table, wide, json, yaml or csv, or CFY_OUTPUT in env (default "table")
+	-jsonpath string
+		Print only fields selected by path from json output
+	-template string
+		Print json output fields by go template
+
+TLS parameters for https connection:
+
+	-ca-cert string
+		Manager CA certificates bundle path or CFY_CA_CERT in env
+	-client-cert string
+		Client certificate path or CFY_CLIENT_CERT in env
+	-client-key string
+		Client certificate key path or CFY_CLIENT_KEY in env
+	-tls-server-name string
+		Manager server name for certificate check or CFY_TLS_SERVER_NAME in env
+	-insecure
+		Skip manager certificate check or CFY_INSECURE in env
+	-cert-fingerprint string
+		Manager certificate sha256 fingerprint or CFY_CERT_FINGERPRINT in env
 
 Example:
 
@@ -65,10 +96,8 @@ Not implemeted commands:
 	ldap: Set LDAP authenticator.
 	logs: Handle manager service logs.
 	maintenance-mode: Handle the manager's maintenance-mode.
-	profiles: Handle Cloudify CLI profiles Each profile can...
 	rollback: Rollback a manager to a previous version.
 	secrets: Handle Cloudify secrets (key-value pairs).
-	snapshots: Handle manager snapshots.
 	ssh: Connect using SSH [manager only].
 	teardown: Teardown a manager [manager only]
 	uninstall: Uninstall an application blueprint [manager only]
@@ -95,7 +124,9 @@ for manage blueprints on the manager:
 
 		cfy-go blueprints list -blueprint blueprint
 
-	inputs - Retrieve blueprint inputs [manager only]. Not Implemented.
+	inputs - Retrieve blueprint inputs [manager only].
+
+		cfy-go blueprints inputs <blueprint id>
 
 	list - List blueprints [manager only]
 
@@ -104,25 +135,82 @@ for manage blueprints on the manager:
 		Paggination by:
 			`-offset`:  the number of resources to skip.
 			`-size`: the max size of the result subset to receive.
+			`-all`: request all pages starting from offset.
 
 	package - Create a blueprint archive. Not Implemented.
 
 	upload - Upload a blueprint [manager only].
+	Directory with blueprint is packed to archive, existing archives
+	are uploaded as is, archive by url is downloaded by manager.
+
 		cfy-go blueprints upload new-blueprint -path <blueprint directory>/<blueprint name>.yaml
+		cfy-go blueprints upload new-blueprint -path <blueprint directory>/<blueprint name>.yaml -archive-format tar.gz -exclude '.git,*.pyc'
+		cfy-go blueprints upload new-blueprint -path <blueprint archive>.tar.gz -blueprint-filename <blueprint name>.yaml
+		cfy-go blueprints upload new-blueprint -url <blueprint archive url> -blueprint-filename <blueprint name>.yaml -visibility global
 
-	validate - Validate a blueprint. Not Implemented.
+	validate - Validate a blueprint and deployment inputs without manager.
+	Imports by url or plugin name are not resolved, so node types from such
+	imports are not checked.
+
+		cfy-go blueprints validate -path <blueprint directory>/<blueprint name>.yaml --inputs '{"ip": "b"}'
 
 */
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
 	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 	"log"
+	"sort"
+	"strings"
 )
 
+func validateBlueprint(blueprintPath string, inputs map[string]interface{}) bool {
+	err := cloudify.ValidateBlueprint(blueprintPath, inputs)
+	if err == nil {
+		return true
+	}
+	if errs, ok := err.(cloudify.BlueprintValidationErrors); ok {
+		for _, problem := range errs {
+			fmt.Printf("Validation error: %s\n", problem.Error())
+		}
+	} else {
+		log.Printf("Validation error: %s\n", err.Error())
+	}
+	return false
+}
+
+func blueprintsTable(blueprints []cloudify.Blueprint) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(blueprints))
+		for pos, blueprint := range blueprints {
+			lines[pos] = make([]string, 7)
+			lines[pos][0] = blueprint.ID
+			lines[pos][1] = blueprint.Description
+			lines[pos][2] = blueprint.MainFileName
+			lines[pos][3] = blueprint.CreatedAt
+			lines[pos][4] = blueprint.UpdatedAt
+			lines[pos][5] = blueprint.Tenant
+			lines[pos][6] = blueprint.CreatedBy
+			if wide {
+				lines[pos] = append(lines[pos], strings.Join(blueprint.Plan.RequiredInputs(), ", "))
+			}
+		}
+		titles := []string{
+			"id", "description", "main_file_name", "created_at",
+			"updated_at", "tenant_name", "created_by",
+		}
+		if wide {
+			titles = append(titles, "required_inputs")
+		}
+		return titles, lines
+	}
+}
+
 func blueprintsOptions(args, options []string) int {
-	defaultError := "list/delete/download/upload subcommand is required"
+	defaultError := "list/delete/download/upload/inputs/validate subcommand is required"
 
 	if len(args) < 3 {
 		fmt.Println(defaultError)
@@ -143,27 +231,20 @@ func blueprintsOptions(args, options []string) int {
 			}
 
 			cl := getClient()
-			blueprints, err := cl.GetBlueprints(params)
+			getBlueprints := cl.GetBlueprints
+			if listAllRequested(operFlagSet) {
+				getBlueprints = cl.GetAllBlueprints
+			}
+			blueprints, err := getBlueprints(params)
 			if err != nil {
 				log.Printf("Cloudify error: %s\n", err.Error())
 				return 1
 			}
-			lines := make([][]string, len(blueprints.Items))
-			for pos, blueprint := range blueprints.Items {
-				lines[pos] = make([]string, 7)
-				lines[pos][0] = blueprint.ID
-				lines[pos][1] = blueprint.Description
-				lines[pos][2] = blueprint.MainFileName
-				lines[pos][3] = blueprint.CreatedAt
-				lines[pos][4] = blueprint.UpdatedAt
-				lines[pos][5] = blueprint.Tenant
-				lines[pos][6] = blueprint.CreatedBy
+			res := printOutput(blueprints.Items, blueprintsTable(blueprints.Items))
+			if res != 0 || !isTableOutput() {
+				return res
 			}
-			utils.PrintTable([]string{
-				"id", "description", "main_file_name", "created_at",
-				"updated_at", "tenant_name", "created_by",
-			}, lines)
-			fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
+			fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
 				blueprints.Metadata.Pagination.Offset, len(blueprints.Items),
 				blueprints.Metadata.Pagination.Total)
 		}
@@ -175,33 +256,48 @@ func blueprintsOptions(args, options []string) int {
 				return 1
 			}
 			var blueprintPath string
+			var archiveURL string
+			var exclude string
+			var upload cloudify.BlueprintUpload
 			operFlagSet.StringVar(&blueprintPath, "path", "",
-				"The blueprint path")
+				"The blueprint path or path to zip, tar, tar.gz or tar.bz2 archive")
+			operFlagSet.StringVar(&archiveURL, "url", "",
+				"The blueprint archive url")
+			operFlagSet.StringVar(&upload.ApplicationFileName, "blueprint-filename", "",
+				"The main blueprint file name in archive")
+			operFlagSet.StringVar(&upload.Visibility, "visibility", "",
+				"The blueprint visibility: private, tenant or global")
+			operFlagSet.StringVar(&upload.ArchiveFormat, "archive-format", utils.ArchiveZip,
+				"Archive format for pack blueprint directory: zip, tar or tar.gz")
+			operFlagSet.StringVar(&exclude, "exclude", "",
+				"Comma separated patterns of files skipped on pack, e.g. '.git,*.pyc'")
 			operFlagSet.Parse(options)
 
-			if len(blueprintPath) < 4 {
-				fmt.Println("Blueprint path required")
+			if archiveURL == "" && len(blueprintPath) < 4 {
+				fmt.Println("Blueprint path or url required")
 				return 1
 			}
+			if exclude != "" {
+				upload.Exclude = strings.Split(exclude, ",")
+			}
+
 			cl := getClient()
-			blueprint, err := cl.UploadBlueprint(args[3], blueprintPath)
+			var blueprint *cloudify.BlueprintGet
+			var err error
+			switch {
+			case archiveURL != "":
+				blueprint, err = cl.UploadBlueprintURL(args[3], archiveURL, upload)
+			case utils.ArchiveFormat(blueprintPath) != "":
+				blueprint, err = cl.UploadBlueprintArchive(args[3], blueprintPath, upload)
+			default:
+				blueprint, err = cl.UploadBlueprintDir(args[3], blueprintPath, upload)
+			}
 			if err != nil {
 				log.Printf("Cloudify error: %s\n", err.Error())
 				return 1
 			}
-			lines := make([][]string, 1)
-			lines[0] = make([]string, 7)
-			lines[0][0] = blueprint.ID
-			lines[0][1] = blueprint.Description
-			lines[0][2] = blueprint.MainFileName
-			lines[0][3] = blueprint.CreatedAt
-			lines[0][4] = blueprint.UpdatedAt
-			lines[0][5] = blueprint.Tenant
-			lines[0][6] = blueprint.CreatedBy
-			utils.PrintTable([]string{
-				"id", "description", "main_file_name", "created_at",
-				"updated_at", "tenant_name", "created_by",
-			}, lines)
+			return printOutput(blueprint.Blueprint,
+				blueprintsTable([]cloudify.Blueprint{blueprint.Blueprint}))
 		}
 	case "download":
 		{
@@ -235,19 +331,78 @@ func blueprintsOptions(args, options []string) int {
 				log.Printf("Cloudify error: %s\n", err.Error())
 				return 1
 			}
-			lines := make([][]string, 1)
-			lines[0] = make([]string, 7)
-			lines[0][0] = blueprint.ID
-			lines[0][1] = blueprint.Description
-			lines[0][2] = blueprint.MainFileName
-			lines[0][3] = blueprint.CreatedAt
-			lines[0][4] = blueprint.UpdatedAt
-			lines[0][5] = blueprint.Tenant
-			lines[0][6] = blueprint.CreatedBy
-			utils.PrintTable([]string{
-				"id", "description", "main_file_name", "created_at",
-				"updated_at", "tenant_name", "created_by",
-			}, lines)
+			return printOutput(blueprint.Blueprint,
+				blueprintsTable([]cloudify.Blueprint{blueprint.Blueprint}))
+		}
+	case "inputs":
+		{
+			operFlagSet := basicOptions("blueprints inputs")
+			if len(args) < 4 {
+				fmt.Println("Blueprint Id required")
+				return 1
+			}
+			operFlagSet.Parse(options)
+
+			cl := getClient()
+			blueprint, err := cl.GetBlueprint(args[3])
+			if err != nil {
+				log.Printf("Cloudify error: %s\n", err.Error())
+				return 1
+			}
+			inputs := blueprint.Plan.Inputs
+			return printOutput(inputs, func(wide bool) ([]string, [][]string) {
+				names := []string{}
+				for name := range inputs {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+
+				lines := [][]string{}
+				for _, name := range names {
+					input := inputs[name]
+					defaultValue := "-"
+					if input.HasDefault() {
+						jsonData, err := json.Marshal(input.Default)
+						if err != nil {
+							log.Printf("Cloudify error: %s\n", err.Error())
+						}
+						defaultValue = string(jsonData)
+					}
+					lines = append(lines, []string{
+						name, input.Type, defaultValue, input.Description,
+					})
+				}
+				return []string{
+					"name", "type", "default", "description",
+				}, lines
+			})
+		}
+	case "validate":
+		{
+			operFlagSet := basicOptions("blueprints validate")
+			var blueprintPath string
+			var jsonInputs string
+			operFlagSet.StringVar(&blueprintPath, "path", "",
+				"The blueprint path")
+			operFlagSet.StringVar(&jsonInputs, "inputs", "{}",
+				"The json input string")
+			operFlagSet.Parse(options)
+
+			if len(blueprintPath) < 4 {
+				fmt.Println("Blueprint path required")
+				return 1
+			}
+
+			var inputs = map[string]interface{}{}
+			if err := json.Unmarshal([]byte(jsonInputs), &inputs); err != nil {
+				log.Printf("Wrong inputs: %s\n", err.Error())
+				return 1
+			}
+
+			if !validateBlueprint(blueprintPath, inputs) {
+				return 1
+			}
+			fmt.Println("Blueprint is valid")
 		}
 	default:
 		{
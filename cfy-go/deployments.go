@@ -23,6 +23,11 @@ deployments - Handle deployments on the Manager
 
 		cfy-go deployments create deployment  -blueprint blueprint --inputs '{"ip": "b"}'
 
+		Inputs are validated against local copy of blueprint before create if
+		`-path` is set:
+
+		cfy-go deployments create deployment  -blueprint blueprint -path <blueprint directory>/<blueprint name>.yaml --inputs '{"ip": "b"}'
+
 	delete - Delete a deployment [manager only]
 		cfy-go deployments delete  deployment
 
@@ -35,12 +40,28 @@ deployments - Handle deployments on the Manager
 	Paggination by:
 		`-offset`:  the number of resources to skip.
 		`-size`: the max size of the result subset to receive.
+		`-all`: request all pages starting from offset.
 
 	outputs - Show deployment outputs [manager only]
 
 		cfy-go deployments outputs -deployment deployment
 
-	update - Update a deployment [manager only]. Not Implemented.
+	update - Update a deployment to new blueprint and inputs [manager only].
+	Blueprint can be set by id of already uploaded blueprint or by path
+	to blueprint, that will be uploaded before update.
+
+		cfy-go deployments update deployment -blueprint new-blueprint --inputs '{"ip": "b"}'
+		cfy-go deployments update deployment -path <blueprint directory>/<blueprint name>.yaml -wait
+
+	Supported options:
+		`-skip-install`, `-skip-uninstall`, `-skip-reinstall`: skip update parts.
+		`-workflow`: custom workflow for update.
+		`-wait`: wait until update finished, show update steps.
+
+	updates - List deployment updates [manager only].
+
+		cfy-go deployments updates -deployment deployment
+		cfy-go deployments updates -update <update id>
 
 	scaling-groups - check limits for scaling group
 
@@ -53,11 +74,13 @@ deployments - Handle deployments on the Manager
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
 	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 	"log"
+	"sort"
 	"strings"
 )
 
@@ -73,29 +96,35 @@ func deploymentsFilter(operFlagSet *flag.FlagSet, options []string) (*cloudify.D
 	}
 
 	cl := getClient()
+	if listAllRequested(operFlagSet) {
+		return cl.GetAllDeployments(params)
+	}
 	return cl.GetDeployments(params)
 }
 
-func groupPrint(deploymentScalingGroups map[string]cloudify.NodeGroup, err error) int {
-	if err != nil {
-		log.Printf("Cloudify error: %s\n", err.Error())
-		return 1
-	}
-
-	lines := make([][]string, len(deploymentScalingGroups))
-	var pos int
-	if deploymentScalingGroups != nil {
-		for groupName, nodeGroup := range deploymentScalingGroups {
+func groupTable(deploymentGroups map[string]cloudify.NodeGroup) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(deploymentGroups))
+		var pos int
+		for groupName, nodeGroup := range deploymentGroups {
 			lines[pos] = make([]string, 2)
 			lines[pos][0] = groupName
 			lines[pos][1] = strings.Join(nodeGroup.Members, ", ")
+			if wide {
+				policies := []string{}
+				for policyName := range nodeGroup.Policies {
+					policies = append(policies, policyName)
+				}
+				sort.Strings(policies)
+				lines[pos] = append(lines[pos], strings.Join(policies, ", "))
+			}
 			pos++
 		}
+		if wide {
+			return []string{"Group name", "Members", "Policies"}, lines
+		}
+		return []string{"Group name", "Members"}, lines
 	}
-	utils.PrintTable([]string{
-		"Group name", "Members",
-	}, lines)
-	return 0
 }
 
 func getDeployment(operFlagSet *flag.FlagSet, options []string) (*cloudify.Deployment, error) {
@@ -109,28 +138,51 @@ func getDeployment(operFlagSet *flag.FlagSet, options []string) (*cloudify.Deplo
 	return &deployments.Items[0], nil
 }
 
-func printDeployments(deployments []cloudify.Deployment) {
-	lines := make([][]string, len(deployments))
-	for pos, deployment := range deployments {
-		var scaleGroups = []string{}
-		if deployment.ScalingGroups != nil {
-			for groupName := range deployment.ScalingGroups {
-				scaleGroups = append(scaleGroups, groupName)
+func printDeployments(deployments []cloudify.Deployment) int {
+	return printOutput(deployments, func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(deployments))
+		for pos, deployment := range deployments {
+			var scaleGroups = []string{}
+			if deployment.ScalingGroups != nil {
+				for groupName := range deployment.ScalingGroups {
+					scaleGroups = append(scaleGroups, groupName)
+				}
+			}
+			lines[pos] = make([]string, 7)
+			lines[pos][0] = deployment.ID
+			lines[pos][1] = deployment.BlueprintID
+			lines[pos][2] = deployment.CreatedAt
+			lines[pos][3] = deployment.UpdatedAt
+			lines[pos][4] = deployment.Tenant
+			lines[pos][5] = deployment.CreatedBy
+			lines[pos][6] = strings.Join(scaleGroups, ", ")
+			if wide {
+				var workflows = []string{}
+				for _, workflow := range deployment.Workflows {
+					workflows = append(workflows, workflow.Name)
+				}
+				lines[pos] = append(lines[pos], deployment.Description,
+					strings.Join(workflows, ", "))
 			}
 		}
-		lines[pos] = make([]string, 7)
-		lines[pos][0] = deployment.ID
-		lines[pos][1] = deployment.BlueprintID
-		lines[pos][2] = deployment.CreatedAt
-		lines[pos][3] = deployment.UpdatedAt
-		lines[pos][4] = deployment.Tenant
-		lines[pos][5] = deployment.CreatedBy
-		lines[pos][6] = strings.Join(scaleGroups, ", ")
+		titles := []string{
+			"id", "blueprint_id", "created_at", "updated_at",
+			"tenant_name", "created_by", "scale_groups",
+		}
+		if wide {
+			titles = append(titles, "description", "workflows")
+		}
+		return titles, lines
+	})
+}
+
+// printDeploymentsPagination - show count of deployments for human only
+func printDeploymentsPagination(deployments *cloudify.Deployments) {
+	if isTableOutput() {
+		fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
+			deployments.Metadata.Pagination.Offset, len(deployments.Items),
+			deployments.Metadata.Pagination.Total)
 	}
-	utils.PrintTable([]string{
-		"id", "blueprint_id", "created_at", "updated_at",
-		"tenant_name", "created_by", "scale_groups",
-	}, lines)
 }
 
 func scalingGroupsDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int {
@@ -139,14 +191,25 @@ func scalingGroupsDeploymentCall(operFlagSet *flag.FlagSet, args, options []stri
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
+	if isTableOutput() {
+		for _, deployment := range deployments.Items {
+			fmt.Printf("Scale group in: %v\n", deployment.ID)
+			scaleGroupPrint(deployment.ScalingGroups, nil)
+		}
+		printDeploymentsPagination(deployments)
+		return 0
+	}
+
+	var scalingGroups = map[string]map[string]cloudify.ScalingGroup{}
+	var deploymentIDs = []string{}
 	for _, deployment := range deployments.Items {
-		fmt.Printf("Scale group in: %v\n", deployment.ID)
-		scaleGroupPrint(deployment.ScalingGroups, nil)
+		scalingGroups[deployment.ID] = deployment.ScalingGroups
+		deploymentIDs = append(deploymentIDs, deployment.ID)
 	}
-	fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
-		deployments.Metadata.Pagination.Offset, len(deployments.Items),
-		deployments.Metadata.Pagination.Total)
-	return 0
+	return printOutput(scalingGroups, groupedTable("Deployment", deploymentIDs,
+		func(deploymentID string) tableBuilder {
+			return scaleGroupTable(scalingGroups[deploymentID])
+		}))
 }
 
 func groupsDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int {
@@ -155,14 +218,25 @@ func groupsDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
+	if isTableOutput() {
+		for _, deployment := range deployments.Items {
+			fmt.Printf("Node Group in: %v\n", deployment.ID)
+			printOutput(deployment.Groups, groupTable(deployment.Groups))
+		}
+		printDeploymentsPagination(deployments)
+		return 0
+	}
+
+	var groups = map[string]map[string]cloudify.NodeGroup{}
+	var deploymentIDs = []string{}
 	for _, deployment := range deployments.Items {
-		fmt.Printf("Node Group in: %v\n", deployment.ID)
-		groupPrint(deployment.Groups, nil)
+		groups[deployment.ID] = deployment.Groups
+		deploymentIDs = append(deploymentIDs, deployment.ID)
 	}
-	fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
-		deployments.Metadata.Pagination.Offset, len(deployments.Items),
-		deployments.Metadata.Pagination.Total)
-	return 0
+	return printOutput(groups, groupedTable("Deployment", deploymentIDs,
+		func(deploymentID string) tableBuilder {
+			return groupTable(groups[deploymentID])
+		}))
 }
 
 func listDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int {
@@ -171,10 +245,10 @@ func listDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int {
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
-	printDeployments(deployments.Items)
-	fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
-		deployments.Metadata.Pagination.Offset, len(deployments.Items),
-		deployments.Metadata.Pagination.Total)
+	if res := printDeployments(deployments.Items); res != 0 {
+		return res
+	}
+	printDeploymentsPagination(deployments)
 	return 0
 }
 
@@ -186,15 +260,25 @@ func createDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int
 
 	var blueprint string
 	var jsonInputs string
+	var blueprintPath string
 	operFlagSet.StringVar(&blueprint, "blueprint", "",
 		"The unique identifier for the blueprint")
 	operFlagSet.StringVar(&jsonInputs, "inputs", "{}",
 		"The json input string")
+	operFlagSet.StringVar(&blueprintPath, "path", "",
+		"Local copy of blueprint for validate inputs before create")
 	operFlagSet.Parse(options)
 
 	var depl cloudify.DeploymentPost
 	depl.BlueprintID = blueprint
-	depl.SetJSONInputs(jsonInputs)
+	if err := depl.SetJSONInputs(jsonInputs); err != nil {
+		log.Printf("Wrong inputs: %s\n", err.Error())
+		return 1
+	}
+
+	if blueprintPath != "" && !validateBlueprint(blueprintPath, depl.Inputs) {
+		return 1
+	}
 
 	cl := getClient()
 	deployment, err := cl.CreateDeployments(args[3], depl)
@@ -202,8 +286,27 @@ func createDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
-	printDeployments([]cloudify.Deployment{deployment.Deployment})
-	return 0
+	return printDeployments([]cloudify.Deployment{deployment.Deployment})
+}
+
+func valuesTable(values map[string]interface{}) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		names := []string{}
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		lines := make([][]string, len(names))
+		for pos, name := range names {
+			jsonData, err := json.Marshal(values[name])
+			if err != nil {
+				log.Printf("Cloudify error: %s\n", err.Error())
+			}
+			lines[pos] = []string{name, string(jsonData)}
+		}
+		return []string{"name", "value"}, lines
+	}
 }
 
 func outputsDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int {
@@ -212,6 +315,9 @@ func outputsDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) in
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
+	if !isTableOutput() {
+		return printOutput(deployment.Outputs, valuesTable(deployment.Outputs))
+	}
 	jsonOutputs, err := deployment.GetJSONOutputs()
 	if err != nil {
 		log.Printf("Cloudify error: %s\n", err.Error())
@@ -227,6 +333,9 @@ func inputsDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
+	if !isTableOutput() {
+		return printOutput(deployment.Inputs, valuesTable(deployment.Inputs))
+	}
 	jsonInputs, err := deployment.GetJSONInputs()
 	if err != nil {
 		log.Printf("Cloudify error: %s\n", err.Error())
@@ -250,7 +359,171 @@ func deleteDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
-	printDeployments([]cloudify.Deployment{deployment.Deployment})
+	return printDeployments([]cloudify.Deployment{deployment.Deployment})
+}
+
+func deploymentUpdatesTable(updates []cloudify.DeploymentUpdate) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(updates))
+		for pos, update := range updates {
+			lines[pos] = make([]string, 7)
+			lines[pos][0] = update.ID
+			lines[pos][1] = update.DeploymentID
+			lines[pos][2] = update.State
+			lines[pos][3] = update.OldBlueprintID
+			lines[pos][4] = update.NewBlueprintID
+			lines[pos][5] = update.ExecutionID
+			lines[pos][6] = update.CreatedAt
+			if wide {
+				lines[pos] = append(lines[pos], fmt.Sprintf("%d", len(update.Steps)))
+			}
+		}
+		titles := []string{
+			"id", "deployment_id", "state", "old_blueprint_id",
+			"new_blueprint_id", "execution_id", "created_at",
+		}
+		if wide {
+			titles = append(titles, "steps")
+		}
+		return titles, lines
+	}
+}
+
+func printDeploymentUpdates(updates []cloudify.DeploymentUpdate) int {
+	return printOutput(updates, deploymentUpdatesTable(updates))
+}
+
+// printDeploymentUpdate - show update with steps, steps are part of update
+// object in machine readable outputs
+func printDeploymentUpdate(update cloudify.DeploymentUpdate) int {
+	if !isTableOutput() {
+		return printOutput(update, deploymentUpdatesTable([]cloudify.DeploymentUpdate{update}))
+	}
+	printDeploymentUpdates([]cloudify.DeploymentUpdate{update})
+
+	lines := make([][]string, len(update.Steps))
+	for pos, step := range update.Steps {
+		lines[pos] = make([]string, 3)
+		lines[pos][0] = step.Action
+		lines[pos][1] = step.EntityType
+		lines[pos][2] = step.EntityID
+	}
+	utils.PrintTable([]string{
+		"action", "entity_type", "entity_id",
+	}, lines)
+	return 0
+}
+
+func updateDeploymentCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Deployment Id required")
+		return 1
+	}
+
+	var update cloudify.DeploymentUpdatePost
+	var blueprintPath string
+	var jsonInputs string
+	var wait bool
+	operFlagSet.StringVar(&update.BlueprintID, "blueprint", "",
+		"The unique identifier for the new blueprint")
+	operFlagSet.StringVar(&blueprintPath, "path", "",
+		"The new blueprint path")
+	operFlagSet.StringVar(&jsonInputs, "inputs", "",
+		"The json input string")
+	operFlagSet.StringVar(&update.WorkflowID, "workflow", "",
+		"Custom workflow for update")
+	operFlagSet.BoolVar(&update.SkipInstall, "skip-install", false,
+		"Skip install of added nodes")
+	operFlagSet.BoolVar(&update.SkipUninstall, "skip-uninstall", false,
+		"Skip uninstall of removed nodes")
+	operFlagSet.BoolVar(&update.SkipReinstall, "skip-reinstall", false,
+		"Skip reinstall of changed nodes")
+	operFlagSet.BoolVar(&wait, "wait", false,
+		"Wait until update finished")
+	operFlagSet.Parse(options)
+
+	if update.BlueprintID == "" && blueprintPath == "" {
+		fmt.Println("Blueprint Id or blueprint path required")
+		return 1
+	}
+
+	if jsonInputs != "" {
+		var depl cloudify.DeploymentPost
+		if err := depl.SetJSONInputs(jsonInputs); err != nil {
+			log.Printf("Wrong inputs: %s\n", err.Error())
+			return 1
+		}
+		update.Inputs = depl.Inputs
+	}
+
+	cl := getClient()
+	var started *cloudify.DeploymentUpdate
+	var err error
+	if blueprintPath != "" {
+		started, err = cl.UpdateDeploymentFromArchive(args[3], blueprintPath, update)
+	} else {
+		started, err = cl.UpdateDeployment(args[3], update)
+	}
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if !wait {
+		return printDeploymentUpdate(*started)
+	}
+	if isTableOutput() {
+		printDeploymentUpdates([]cloudify.DeploymentUpdate{*started})
+	}
+
+	result, err := cl.WaitDeploymentUpdate(started.ID)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if res := printDeploymentUpdate(result.Update); res != 0 {
+		return res
+	}
+	if result.State != cloudify.WaitTerminated {
+		log.Printf("Deployment update %s %s\n", result.Update.ID, result.State)
+		return 1
+	}
+	return 0
+}
+
+func listDeploymentUpdatesCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	var deployment string
+	var updateID string
+	operFlagSet.StringVar(&deployment, "deployment", "",
+		"The unique identifier for the deployment")
+	operFlagSet.StringVar(&updateID, "update", "",
+		"The unique identifier for the deployment update")
+
+	params := parsePagination(operFlagSet, options)
+
+	cl := getClient()
+	if updateID != "" {
+		update, err := cl.GetDeploymentUpdate(updateID)
+		if err != nil {
+			log.Printf("Cloudify error: %s\n", err.Error())
+			return 1
+		}
+		return printDeploymentUpdate(*update)
+	}
+
+	if deployment != "" {
+		params["deployment_id"] = deployment
+	}
+	updates, err := cl.GetDeploymentUpdates(params)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if res := printDeploymentUpdates(updates.Items); res != 0 || !isTableOutput() {
+		return res
+	}
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
+		updates.Metadata.Pagination.Offset, len(updates.Items),
+		updates.Metadata.Pagination.Total)
 	return 0
 }
 
@@ -276,6 +549,12 @@ func deploymentsOptions(args, options []string) int {
 	}, {
 		CommandName: "list",
 		Callback:    listDeploymentCall,
+	}, {
+		CommandName: "update",
+		Callback:    updateDeploymentCall,
+	}, {
+		CommandName: "updates",
+		Callback:    listDeploymentUpdatesCall,
 	}}
 
 	return ParseCalls(pluginsCalls, 3, args, options)
@@ -28,22 +28,167 @@ events - Show events from workflow executions
 	Paggination by:
 		`-offset`:  the number of resources to skip.
 		`-size`: the max size of the result subset to receive.
+		`-all`: request all pages starting from offset.
 
 	Supported filters:
 		`blueprint`: The unique identifier for the blueprint
 		`deployment`: The unique identifier for the deployment
 		`execution`: The unique identifier for the execution
+
+	tail - Show events of execution as they arrive until execution finished [manager only]
+
+		cfy-go events tail -execution <execution id>
+
+	Exit code: 0 - terminated, 1 - failed or error, 2 - cancelled, 3 - timed out.
+
+	Supported options:
+		`-timeout`: stop waiting after timeout, e.g. 10m. Zero means wait forever.
+		`-no-color`: disable colored output.
 */
 package main
 
 import (
+	"flag"
 	"fmt"
-	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
 	"log"
+	"os"
+	"time"
+)
+
+const (
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorBlue   = "\x1b[34m"
 )
 
+// eventColor - color for event by log level or event type
+func eventColor(event cloudify.Event) string {
+	switch event.Level {
+	case "error", "critical":
+		return colorRed
+	case "warning":
+		return colorYellow
+	case "debug":
+		return colorBlue
+	}
+	switch event.EventType {
+	case "workflow_failed", "task_failed", "workflow_cancelled":
+		return colorRed
+	case "task_rescheduled":
+		return colorYellow
+	case "workflow_succeeded", "task_succeeded":
+		return colorGreen
+	}
+	return ""
+}
+
+// eventLine - event as one line, type for cloudify event or level for log message
+func eventLine(event cloudify.Event) string {
+	kind := event.EventType
+	if kind == "" {
+		kind = event.Level
+	}
+	line := fmt.Sprintf("%s [%s]", event.Timestamp, kind)
+	if event.NodeInstanceID != "" {
+		line += " " + event.NodeInstanceID
+	}
+	if event.Operation != "" {
+		line += " " + event.Operation
+	}
+	return line + ": " + event.Message
+}
+
+// isTerminal - file is connected to terminal, colors and progress bar
+// can be used
+func isTerminal(file *os.File) bool {
+	stat, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
+
+// followFlags - add options used for follow execution events
+func followFlags(operFlagSet *flag.FlagSet) (*time.Duration, *bool) {
+	timeout := operFlagSet.Duration("timeout", 0,
+		"Stop waiting after timeout, zero means wait forever")
+	noColor := operFlagSet.Bool("no-color", false,
+		"Disable colored output")
+	return timeout, noColor
+}
+
+func eventsTable(events []cloudify.Event) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(events))
+		for pos, event := range events {
+			lines[pos] = make([]string, 5)
+			lines[pos][0] = event.Timestamp
+			lines[pos][1] = event.DeploymentID
+			lines[pos][2] = event.NodeInstanceID
+			lines[pos][3] = event.Operation
+			lines[pos][4] = event.Message
+			if wide {
+				lines[pos] = append(lines[pos], event.ExecutionID,
+					event.Type, event.EventType, event.Level)
+			}
+		}
+		titles := []string{
+			"Timestamp", "Deployment", "InstanceId", "Operation",
+			"Message",
+		}
+		if wide {
+			titles = append(titles, "Execution", "Type", "Event type", "Level")
+		}
+		return titles, lines
+	}
+}
+
+// followExecution - print execution events until execution finished,
+// return exit code by final execution status
+func followExecution(cl *cloudify.Client, executionID string, timeout time.Duration, colored bool) int {
+	waiter := cloudify.NewExecutionWaiter()
+	waiter.MaxPollInterval = 5 * time.Second
+	waiter.Timeout = timeout
+	waiter.OnEvent = func(event cloudify.Event) {
+		if !isTableOutput() {
+			printOutput(event, eventsTable([]cloudify.Event{event}))
+			return
+		}
+		color := eventColor(event)
+		if colored && color != "" {
+			fmt.Println(color + eventLine(event) + colorReset)
+		} else {
+			fmt.Println(eventLine(event))
+		}
+	}
+	cl.SetExecutionWaiter(waiter)
+
+	result, err := cl.WaitExecution(executionID)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if isTableOutput() {
+		fmt.Printf("Execution %s %s\n", executionID, result.State)
+	} else {
+		log.Printf("Execution %s %s\n", executionID, result.State)
+	}
+	switch result.State {
+	case cloudify.WaitTerminated:
+		return 0
+	case cloudify.WaitCancelled:
+		return 2
+	case cloudify.WaitTimedOut:
+		return 3
+	}
+	return 1
+}
+
 func eventsOptions(args, options []string) int {
-	defaultError := "list subcommand is required"
+	defaultError := "list/tail subcommand is required"
 
 	if len(args) < 3 {
 		fmt.Println(defaultError)
@@ -77,28 +222,40 @@ func eventsOptions(args, options []string) int {
 			}
 
 			cl := getClient()
-			events, err := cl.GetEvents(params)
+			getEvents := cl.GetEvents
+			if listAllRequested(operFlagSet) {
+				getEvents = cl.GetAllEvents
+			}
+			events, err := getEvents(params)
 			if err != nil {
 				log.Printf("Cloudify error: %s\n", err.Error())
 				return 1
 			}
-			lines := make([][]string, len(events.Items))
-			for pos, event := range events.Items {
-				lines[pos] = make([]string, 5)
-				lines[pos][0] = event.Timestamp
-				lines[pos][1] = event.DeploymentID
-				lines[pos][2] = event.NodeInstanceID
-				lines[pos][3] = event.Operation
-				lines[pos][4] = event.Message
+			res := printOutput(events.Items, eventsTable(events.Items))
+			if res != 0 || !isTableOutput() {
+				return res
 			}
-			utils.PrintTable([]string{
-				"Timestamp", "Deployment", "InstanceId", "Operation",
-				"Message",
-			}, lines)
-			fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
+			fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
 				events.Metadata.Pagination.Offset, len(events.Items),
 				events.Metadata.Pagination.Total)
 		}
+	case "tail":
+		{
+			operFlagSet := basicOptions("events tail")
+			var execution string
+			operFlagSet.StringVar(&execution, "execution", "",
+				"The unique identifier for the execution")
+			timeout, noColor := followFlags(operFlagSet)
+			operFlagSet.Parse(options)
+
+			if execution == "" {
+				fmt.Println("Execution Id required")
+				return 1
+			}
+
+			cl := getClient()
+			return followExecution(cl, execution, *timeout, !*noColor && isTerminal(os.Stdout))
+		}
 	default:
 		{
 			fmt.Println(defaultError)
@@ -0,0 +1,35 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
+)
+
+func Example_eventLine() {
+	var event cloudify.Event
+	event.Timestamp = "2017-10-18 12:00:00.000"
+	event.EventType = "task_failed"
+	event.NodeInstanceID = "vm_a1b2c3"
+	event.Operation = "cloudify.interfaces.lifecycle.create"
+	event.Message = "Task failed"
+	fmt.Println(eventLine(event))
+	fmt.Println(eventColor(event) == colorRed)
+	// Output: 2017-10-18 12:00:00.000 [task_failed] vm_a1b2c3 cloudify.interfaces.lifecycle.create: Task failed
+	// true
+}
@@ -19,9 +19,15 @@ Executions
 
 executions - Handle workflow executions
 
-	cancel: Cancel a workflow execution [manager only]. Not Implemented.
+	cancel: Cancel a workflow execution [manager only].
 
-	get: Retrieve execution information [manager only]. Not Implemented.
+		cfy-go executions cancel <execution id>
+		cfy-go executions cancel <execution id> -force
+		cfy-go executions cancel <execution id> -kill
+
+	get: Retrieve execution information [manager only].
+
+		cfy-go executions get <execution id>
 
 	list: List deployment executions [manager only].
 
@@ -31,22 +37,75 @@ executions - Handle workflow executions
 	Paggination by:
 		`-offset`:  the number of resources to skip.
 		`-size`: the max size of the result subset to receive.
+		`-all`: request all pages starting from offset.
+
+	resume: Resume a failed or cancelled execution [manager only].
+
+		cfy-go executions resume <execution id>
+		cfy-go executions resume <execution id> -force
 
 	start: Execute a workflow [manager only]. Partially implemented, you can set params only as json string.
 
 		cfy-go executions start uninstall -deployment deployment
+		cfy-go executions start install -deployment deployment -follow
+
+	Use `-follow` for show events of execution until execution finished,
+	exit code is same as for `events tail`.
 */
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
-	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 	"log"
+	"os"
 )
 
+func executionsTable(executions []cloudify.Execution) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(executions))
+		for pos, execution := range executions {
+			lines[pos] = make([]string, 8)
+			lines[pos][0] = execution.ID
+			lines[pos][1] = execution.WorkflowID
+			lines[pos][2] = execution.Status
+			lines[pos][3] = execution.DeploymentID
+			lines[pos][4] = execution.CreatedAt
+			lines[pos][5] = execution.ErrorMessage
+			lines[pos][6] = execution.Tenant
+			lines[pos][7] = execution.CreatedBy
+			if wide {
+				parameters, err := json.Marshal(execution.Parameters)
+				if err != nil {
+					log.Printf("Cloudify error: %s\n", err.Error())
+				}
+				lines[pos] = append(lines[pos], execution.BlueprintID,
+					fmt.Sprintf("%v", execution.IsSystemWorkflow), string(parameters))
+			}
+		}
+		titles := []string{
+			"id", "workflow_id", "status", "deployment_id", "created_at",
+			"error", "tenant_name", "created_by",
+		}
+		if wide {
+			titles = append(titles, "blueprint_id", "is_system_workflow", "parameters")
+		}
+		return titles, lines
+	}
+}
+
+func executionPrint(execution *cloudify.Execution, err error) int {
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+
+	return printOutput(execution, executionsTable([]cloudify.Execution{*execution}))
+}
+
 func executionsOptions(args, options []string) int {
-	defaultError := "list/start subcommand is required"
+	defaultError := "list/start/get/cancel/resume subcommand is required"
 
 	if len(args) < 3 {
 		fmt.Println(defaultError)
@@ -61,7 +120,6 @@ func executionsOptions(args, options []string) int {
 			var deployment string
 			operFlagSet.StringVar(&deployment, "deployment", "",
 				"The unique identifier for the deployment")
-			operFlagSet.Parse(options)
 
 			params := parsePagination(operFlagSet, options)
 
@@ -70,28 +128,20 @@ func executionsOptions(args, options []string) int {
 			}
 
 			cl := getClient()
-			executions, err := cl.GetExecutions(params)
+			getExecutions := cl.GetExecutions
+			if listAllRequested(operFlagSet) {
+				getExecutions = cl.GetAllExecutions
+			}
+			executions, err := getExecutions(params)
 			if err != nil {
 				log.Printf("Cloudify error: %s\n", err.Error())
 				return 1
 			}
-			lines := make([][]string, len(executions.Items))
-			for pos, execution := range executions.Items {
-				lines[pos] = make([]string, 8)
-				lines[pos][0] = execution.ID
-				lines[pos][1] = execution.WorkflowID
-				lines[pos][2] = execution.Status
-				lines[pos][3] = execution.DeploymentID
-				lines[pos][4] = execution.CreatedAt
-				lines[pos][5] = execution.ErrorMessage
-				lines[pos][6] = execution.Tenant
-				lines[pos][7] = execution.CreatedBy
+			res := printOutput(executions.Items, executionsTable(executions.Items))
+			if res != 0 || !isTableOutput() {
+				return res
 			}
-			utils.PrintTable([]string{
-				"id", "workflow_id", "status", "deployment_id", "created_at",
-				"error", "tenant_name", "created_by",
-			}, lines)
-			fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
+			fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
 				executions.Metadata.Pagination.Offset, len(executions.Items),
 				executions.Metadata.Pagination.Total)
 		}
@@ -109,6 +159,10 @@ func executionsOptions(args, options []string) int {
 				"The unique identifier for the deployment")
 			operFlagSet.StringVar(&jsonParams, "params", "{}",
 				"The json params string")
+			var follow bool
+			operFlagSet.BoolVar(&follow, "follow", false,
+				"Show events until execution finished")
+			timeout, noColor := followFlags(operFlagSet)
 			operFlagSet.Parse(options)
 
 			var exec cloudify.ExecutionPost
@@ -122,21 +176,60 @@ func executionsOptions(args, options []string) int {
 				log.Printf("Cloudify error: %s\n", err.Error())
 				return 1
 			}
+			if !follow {
+				return executionPrint(&execution.Execution, nil)
+			}
+			if isTableOutput() {
+				executionPrint(&execution.Execution, nil)
+			}
+			return followExecution(cl, execution.ID, *timeout, !*noColor && isTerminal(os.Stdout))
+		}
+	case "get":
+		{
+			operFlagSet := basicOptions("executions get <execution id>")
+			if len(args) < 4 {
+				fmt.Println("Execution Id required")
+				return 1
+			}
+			operFlagSet.Parse(options)
+
+			cl := getClient()
+			return executionPrint(cl.GetExecution(args[3]))
+		}
+	case "cancel":
+		{
+			operFlagSet := basicOptions("executions cancel <execution id>")
+			if len(args) < 4 {
+				fmt.Println("Execution Id required")
+				return 1
+			}
 
-			lines := make([][]string, 1)
-			lines[0] = make([]string, 8)
-			lines[0][0] = execution.ID
-			lines[0][1] = execution.WorkflowID
-			lines[0][2] = execution.Status
-			lines[0][3] = execution.DeploymentID
-			lines[0][4] = execution.CreatedAt
-			lines[0][5] = execution.ErrorMessage
-			lines[0][6] = execution.Tenant
-			lines[0][7] = execution.CreatedBy
-			utils.PrintTable([]string{
-				"id", "workflow_id", "status", "deployment_id", "created_at",
-				"error", "tenant_name", "created_by",
-			}, lines)
+			var force bool
+			var kill bool
+			operFlagSet.BoolVar(&force, "force", false,
+				"Force cancel execution")
+			operFlagSet.BoolVar(&kill, "kill", false,
+				"Kill execution processes")
+			operFlagSet.Parse(options)
+
+			cl := getClient()
+			return executionPrint(cl.CancelExecution(args[3], force, kill))
+		}
+	case "resume":
+		{
+			operFlagSet := basicOptions("executions resume <execution id>")
+			if len(args) < 4 {
+				fmt.Println("Execution Id required")
+				return 1
+			}
+
+			var force bool
+			operFlagSet.BoolVar(&force, "force", false,
+				"Force resume execution")
+			operFlagSet.Parse(options)
+
+			cl := getClient()
+			return executionPrint(cl.ResumeExecution(args[3], force))
 		}
 	default:
 		{
@@ -46,7 +46,6 @@ import (
 	"flag"
 	"fmt"
 	"github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
-	"github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 	"log"
 	"os"
 )
@@ -56,16 +55,23 @@ func servicesPrint(stat *cloudify.Status, err error) int {
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
-	fmt.Printf("Manager status: %v\n", stat.Status)
-	fmt.Printf("Services:\n")
-	lines := make([][]string, len(stat.Services))
-	for pos, service := range stat.Services {
-		lines[pos] = make([]string, 2)
-		lines[pos][0] = service.DisplayName
-		lines[pos][1] = service.Status()
+	if isTableOutput() {
+		fmt.Printf("Manager status: %v\n", stat.Status)
+		fmt.Printf("Services:\n")
 	}
-	utils.PrintTable([]string{"service", "status"}, lines)
-	return 0
+	return printOutput(stat, func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(stat.Services))
+		for pos, service := range stat.Services {
+			lines[pos] = []string{service.DisplayName, service.Status()}
+			if wide {
+				lines[pos] = append(lines[pos], fmt.Sprintf("%d", len(service.Instances)))
+			}
+		}
+		if wide {
+			return []string{"service", "status", "instances"}, lines
+		}
+		return []string{"service", "status"}, lines
+	})
 }
 
 func versionPrint(ver *cloudify.Version, err error) int {
@@ -74,37 +80,40 @@ func versionPrint(ver *cloudify.Version, err error) int {
 		return 1
 	}
 
-	utils.PrintTable([]string{"Version", "Edition"},
-		[][]string{{ver.Version, ver.Edition}})
-	return 0
+	return printOutput(ver, func(wide bool) ([]string, [][]string) {
+		return []string{"Version", "Edition"},
+			[][]string{{ver.Version, ver.Edition}}
+	})
 }
 
-func instancesChecksPrint(nodeInstances *cloudify.NodeInstances, additional []string) {
-	lines := make([][]string, len(nodeInstances.Items))
-	for pos, nodeInstance := range nodeInstances.Items {
-		lines[pos] = make([]string, 7+len(additional))
-		lines[pos][0] = nodeInstance.ID
-		lines[pos][1] = nodeInstance.DeploymentID
-		lines[pos][2] = nodeInstance.HostID
-		lines[pos][3] = nodeInstance.NodeID
-		lines[pos][4] = nodeInstance.State
-		lines[pos][5] = nodeInstance.GetStringProperty("hostname")
-
-		for col, name := range additional {
-			lines[pos][6+col] = nodeInstance.GetStringProperty(name)
+func instancesChecksPrint(nodeInstances *cloudify.NodeInstances, additional []string) int {
+	return printOutput(nodeInstances.Items, func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(nodeInstances.Items))
+		for pos, nodeInstance := range nodeInstances.Items {
+			lines[pos] = make([]string, 7+len(additional))
+			lines[pos][0] = nodeInstance.ID
+			lines[pos][1] = nodeInstance.DeploymentID
+			lines[pos][2] = nodeInstance.HostID
+			lines[pos][3] = nodeInstance.NodeID
+			lines[pos][4] = nodeInstance.State
+			lines[pos][5] = nodeInstance.GetStringProperty("hostname")
+
+			for col, name := range additional {
+				lines[pos][6+col] = nodeInstance.GetStringProperty(name)
+			}
+
+			lines[pos][6+len(additional)] = "looks good"
+			if len(lines[pos][5]) >= 60 {
+				lines[pos][6+len(additional)] = "Possible issues with nodes registration"
+			}
 		}
+		headers := []string{"Id", "Deployment id", "Host id", "Node id",
+			"State", "HostName"}
 
-		lines[pos][6+len(additional)] = "looks good"
-		if len(lines[pos][5]) >= 60 {
-			lines[pos][6+len(additional)] = "Possible issues with nodes registration"
-		}
-	}
-	headers := []string{"Id", "Deployment id", "Host id", "Node id",
-		"State", "HostName"}
-
-	headers = append(headers, additional...)
-	headers = append(headers, "Note")
-	utils.PrintTable(headers, lines)
+		headers = append(headers, additional...)
+		headers = append(headers, "Note")
+		return headers, lines
+	})
 }
 
 func instancesChecks(cl *cloudify.Client, params map[string]string, typeName string, additionalProperties []string) int {
@@ -114,40 +123,43 @@ func instancesChecks(cl *cloudify.Client, params map[string]string, typeName str
 		return 1
 	}
 
-	if len(nodeInstances.Items) == 0 {
+	if len(nodeInstances.Items) == 0 && isTableOutput() {
 		fmt.Printf("You don't have %v in current deployment.\n", typeName)
 		return 0
 	}
 
-	instancesChecksPrint(nodeInstances, additionalProperties)
-	return 0
+	return instancesChecksPrint(nodeInstances, additionalProperties)
 }
 
-func groupInstancesChecksPrint(nodes *cloudify.NodeWithGroups) {
-	lines := [][]string{}
+func groupInstancesChecksPrint(nodes *cloudify.NodeWithGroups) int {
+	checked := []cloudify.NodeWithGroup{}
 	for _, node := range nodes.Items {
-
-		if node.Type != cloudify.KubernetesNode && node.Type != cloudify.KubernetesLoadBalancer {
-			continue
+		if node.Type == cloudify.KubernetesNode || node.Type == cloudify.KubernetesLoadBalancer {
+			checked = append(checked, node)
 		}
+	}
 
-		var line = make([]string, 7)
-		line[0] = node.ID
-		line[1] = node.DeploymentID
-		line[2] = node.HostID
-		line[3] = node.Type
-		line[4] = node.GroupName
-		line[5] = node.ScalingGroupName
-		line[6] = "looks good"
-		if node.ScalingGroupName == "" || node.GroupName == "" {
-			line[6] = "unscalable"
+	return printOutput(checked, func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(checked))
+		for pos, node := range checked {
+			var line = make([]string, 7)
+			line[0] = node.ID
+			line[1] = node.DeploymentID
+			line[2] = node.HostID
+			line[3] = node.Type
+			line[4] = node.GroupName
+			line[5] = node.ScalingGroupName
+			line[6] = "looks good"
+			if node.ScalingGroupName == "" || node.GroupName == "" {
+				line[6] = "unscalable"
+			}
+
+			lines[pos] = line
 		}
-
-		lines = append(lines, line)
-	}
-	utils.PrintTable([]string{
-		"Id", "Deployment id", "Host id", "Type", "Group", "Scaling Group", "Notes",
-	}, lines)
+		return []string{
+			"Id", "Deployment id", "Host id", "Type", "Group", "Scaling Group", "Notes",
+		}, lines
+	})
 }
 
 func groupInstancesChecks(cl *cloudify.Client, params map[string]string) int {
@@ -157,13 +169,19 @@ func groupInstancesChecks(cl *cloudify.Client, params map[string]string) int {
 		return 1
 	}
 
-	if len(nodes.Items) == 0 {
+	if len(nodes.Items) == 0 && isTableOutput() {
 		fmt.Println("You don't have nodes in current deployment.")
 		return 0
 	}
 
-	groupInstancesChecksPrint(nodes)
-	return 0
+	return groupInstancesChecksPrint(nodes)
+}
+
+func printCheckTitle(title, recheck string) {
+	if isTableOutput() {
+		fmt.Printf("* %s\n", title)
+		fmt.Printf("  Recheck by %s\n", recheck)
+	}
 }
 
 func runChecks(params map[string]string) int {
@@ -182,8 +200,7 @@ func runChecksNode(params map[string]string) int {
 		nodeType = cloudify.KubernetesNode
 	}
 
-	fmt.Println("* Check properties in kubernetes instances.")
-	fmt.Println("  Recheck by 'cfy-go node-instances started'")
+	printCheckTitle("Check properties in kubernetes instances.", "'cfy-go node-instances started'")
 	res = instancesChecks(cl, params, nodeType,
 		[]string{"ip", "public_ip"})
 	if res != 0 {
@@ -200,8 +217,7 @@ func runChecksLoad(params map[string]string) int {
 		loadType = cloudify.KubernetesLoadBalancer
 	}
 
-	fmt.Println("* Check properties in kubernetes loadbalancers.")
-	fmt.Println("  Recheck by 'cfy-go node-instances loadbalancer'")
+	printCheckTitle("Check properties in kubernetes loadbalancers.", "'cfy-go node-instances loadbalancer'")
 	res = instancesChecks(cl, params, loadType,
 		[]string{"ip", "public_ip", "proxy_cluster", "proxy_namespace", "proxy_name"})
 	if res != 0 {
@@ -214,8 +230,7 @@ func runChecksStatus() int {
 	cl := getClient()
 	var res int
 
-	fmt.Println("* Check manager services status.")
-	fmt.Println("  Recheck by 'cfy-go status state'")
+	printCheckTitle("Check manager services status.", "'cfy-go status state'")
 	res = servicesPrint(cl.GetStatus())
 	if res != 0 {
 		return res
@@ -227,8 +242,7 @@ func runChecksScaleGroup(params map[string]string) int {
 	cl := getClient()
 	var res int
 
-	fmt.Println("* Check scale group.")
-	fmt.Println("  Recheck by 'cfy-go nodes group'")
+	printCheckTitle("Check scale group.", "'cfy-go nodes group'")
 	res = groupInstancesChecks(cl, params)
 	if res != 0 {
 		return res
@@ -53,53 +53,81 @@ import (
 	"flag"
 	"fmt"
 	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
-	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 	"log"
 	"os"
 	"strings"
 )
 
+func nodeInstancesTable(nodeInstances []cloudify.NodeInstance) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(nodeInstances))
+		for pos, nodeInstance := range nodeInstances {
+			var scaleGroups = []string{}
+			if nodeInstance.ScalingGroups != nil {
+				for _, scaleGroup := range nodeInstance.ScalingGroups {
+					scaleGroups = append(scaleGroups, scaleGroup.Name)
+				}
+			}
+			jsonString, err := nodeInstance.GetJSONRuntimeProperties()
+			if err != nil {
+				log.Printf("Cloudify error: %s\n", err.Error())
+				jsonString = ""
+			}
+
+			// show full properties only in wide output
+			var propertiesString = jsonString
+			if len(jsonString) > 40 && !wide {
+				propertiesString = jsonString[0:37] + "..."
+			}
+
+			lines[pos] = make([]string, 9)
+			lines[pos][0] = nodeInstance.ID
+			lines[pos][1] = nodeInstance.DeploymentID
+			lines[pos][2] = nodeInstance.HostID
+			lines[pos][3] = nodeInstance.NodeID
+			lines[pos][4] = nodeInstance.State
+			lines[pos][5] = nodeInstance.Tenant
+			lines[pos][6] = nodeInstance.CreatedBy
+			lines[pos][7] = strings.Join(scaleGroups, ", ")
+			lines[pos][8] = propertiesString
+		}
+		return []string{
+			"Id", "Deployment id", "Host id", "Node id", "State", "Tenant",
+			"Created by", "Scaling Group", "Properties",
+		}, lines
+	}
+}
+
 func nodeInstancesPrint(nodeInstances *cloudify.NodeInstances, err error) int {
 	if err != nil {
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
 
-	lines := make([][]string, len(nodeInstances.Items))
-	for pos, nodeInstance := range nodeInstances.Items {
-		var scaleGroups = []string{}
-		if nodeInstance.ScalingGroups != nil {
-			for _, scaleGroup := range nodeInstance.ScalingGroups {
-				scaleGroups = append(scaleGroups, scaleGroup.Name)
-			}
-		}
-		jsonString, err := nodeInstance.GetJSONRuntimeProperties()
-		if err != nil {
-			log.Printf("Cloudify error: %s\n", err.Error())
-			jsonString = ""
-		}
+	return printOutput(nodeInstances.Items, nodeInstancesTable(nodeInstances.Items))
+}
 
-		var propertiesString = jsonString
-		if len(jsonString) > 40 {
-			propertiesString = jsonString[0:37] + "..."
+func groupedInstancesPrint(groupTitle string, groupedInstances map[string]cloudify.NodeInstances) int {
+	if isTableOutput() {
+		for groupName, instances := range groupedInstances {
+			fmt.Printf("%s: %v\n", groupTitle, groupName)
+			if nodeInstancesPrint(&instances, nil) != 0 {
+				return 1
+			}
 		}
+		return 0
+	}
 
-		lines[pos] = make([]string, 9)
-		lines[pos][0] = nodeInstance.ID
-		lines[pos][1] = nodeInstance.DeploymentID
-		lines[pos][2] = nodeInstance.HostID
-		lines[pos][3] = nodeInstance.NodeID
-		lines[pos][4] = nodeInstance.State
-		lines[pos][5] = nodeInstance.Tenant
-		lines[pos][6] = nodeInstance.CreatedBy
-		lines[pos][7] = strings.Join(scaleGroups, ", ")
-		lines[pos][8] = propertiesString
+	var grouped = map[string][]cloudify.NodeInstance{}
+	var groupNames = []string{}
+	for groupName, instances := range groupedInstances {
+		grouped[groupName] = instances.Items
+		groupNames = append(groupNames, groupName)
 	}
-	utils.PrintTable([]string{
-		"Id", "Deployment id", "Host id", "Node id", "State", "Tenant",
-		"Created by", "Scaling Group", "Properties",
-	}, lines)
-	return 0
+	return printOutput(grouped, groupedTable(groupTitle, groupNames,
+		func(groupName string) tableBuilder {
+			return nodeInstancesTable(grouped[groupName])
+		}))
 }
 
 func parseInstancesFlags(operFlagSet *flag.FlagSet, options []string) map[string]string {
@@ -120,8 +148,6 @@ func parseInstancesFlags(operFlagSet *flag.FlagSet, options []string) map[string
 	operFlagSet.StringVar(&hostID, "host-id", "",
 		"Filter by hostID")
 
-	operFlagSet.Parse(options)
-
 	params := parsePagination(operFlagSet, options)
 
 	if instance != "" {
@@ -163,13 +189,7 @@ func nodeInstancesOptions(args, options []string) int {
 				log.Printf("Cloudify error: %s\n", err.Error())
 				return 1
 			}
-			for nodeID, instances := range groupedInstances {
-				fmt.Printf("NodeID: %v\n", nodeID)
-				if nodeInstancesPrint(&instances, nil) != 0 {
-					return 1
-				}
-			}
-			return 0
+			return groupedInstancesPrint("NodeID", groupedInstances)
 		}
 	case "host-grouped":
 		{
@@ -183,13 +203,7 @@ func nodeInstancesOptions(args, options []string) int {
 				log.Printf("Cloudify error: %s\n", err.Error())
 				return 1
 			}
-			for hostID, instances := range groupedInstances {
-				fmt.Printf("HostID: %v\n", hostID)
-				if nodeInstancesPrint(&instances, nil) != 0 {
-					return 1
-				}
-			}
-			return 0
+			return groupedInstancesPrint("HostID", groupedInstances)
 		}
 	case "loadbalancer":
 		{
@@ -282,11 +296,18 @@ func nodeInstancesOptions(args, options []string) int {
 			params := parseInstancesFlags(operFlagSet, options)
 
 			cl := getClient()
-			nodeInstances, err := cl.GetNodeInstances(params)
+			getNodeInstances := cl.GetNodeInstances
+			if listAllRequested(operFlagSet) {
+				getNodeInstances = cl.GetAllNodeInstances
+			}
+			nodeInstances, err := getNodeInstances(params)
 			if nodeInstancesPrint(nodeInstances, err) != 0 {
 				return 1
 			}
-			fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
+			if !isTableOutput() {
+				return 0
+			}
+			fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
 				nodeInstances.Metadata.Pagination.Offset, len(nodeInstances.Items),
 				nodeInstances.Metadata.Pagination.Total)
 			if len(nodeInstances.Items) == 1 {
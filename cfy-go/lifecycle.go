@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Install
+
+install - Upload blueprint, create deployment and run install workflow [manager only].
+Blueprint has same name as deployment. Blueprint and inputs are validated
+before upload. Blueprint and deployment are removed if install workflow was
+not started.
+
+		cfy-go install deployment -path <blueprint directory>/<blueprint name>.yaml --inputs '{"ip": "b"}'
+
+uninstall - Run uninstall workflow, delete deployment and blueprint [manager only].
+
+		cfy-go uninstall deployment
+		cfy-go uninstall deployment -keep-blueprint
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+func printLifecycleStep(step, message string) {
+	fmt.Printf("%s: %s\n", step, message)
+}
+
+func installOptions(args, options []string) int {
+	operFlagSet := basicOptions("install <deployment id>")
+	if len(args) < 3 {
+		fmt.Println("Deployment Id required")
+		return 1
+	}
+
+	var blueprintPath string
+	var jsonInputs string
+	operFlagSet.StringVar(&blueprintPath, "path", "",
+		"The blueprint path")
+	operFlagSet.StringVar(&jsonInputs, "inputs", "{}",
+		"The json input string")
+	operFlagSet.Parse(options)
+
+	if len(blueprintPath) < 4 {
+		fmt.Println("Blueprint path required")
+		return 1
+	}
+
+	var inputs = map[string]interface{}{}
+	if err := json.Unmarshal([]byte(jsonInputs), &inputs); err != nil {
+		log.Printf("Wrong inputs: %s\n", err.Error())
+		return 1
+	}
+
+	if !validateBlueprint(blueprintPath, inputs) {
+		return 1
+	}
+
+	cl := getClient()
+	cl.SetLifecycleHandler(printLifecycleStep)
+	result, err := cl.Install(blueprintPath, args[2], inputs)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return executionPrint(&result.Execution, nil)
+}
+
+func uninstallOptions(args, options []string) int {
+	operFlagSet := basicOptions("uninstall <deployment id>")
+	if len(args) < 3 {
+		fmt.Println("Deployment Id required")
+		return 1
+	}
+
+	var keepBlueprint bool
+	operFlagSet.BoolVar(&keepBlueprint, "keep-blueprint", false,
+		"Don't delete blueprint after deployment delete")
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	cl.SetLifecycleHandler(printLifecycleStep)
+	if err := cl.Uninstall(args[2], !keepBlueprint); err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
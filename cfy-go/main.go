@@ -23,6 +23,7 @@ import (
 	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -32,41 +33,78 @@ func basicOptions(name string) *flag.FlagSet {
 	var commonFlagSet *flag.FlagSet
 	commonFlagSet = flag.NewFlagSet(name, flag.ExitOnError)
 
-	var defaultHost = os.Getenv("CFY_HOST")
-	if defaultHost == "" {
-		defaultHost = "localhost"
-	}
-	commonFlagSet.StringVar(&cloudConfig.Host, "host", defaultHost,
+	// option is already used for select profile, registered for help only
+	commonFlagSet.String("profile", "",
+		"Profile from config file or CFY_PROFILE in env")
+	profile := currentProfile()
+
+	commonFlagSet.StringVar(&cloudConfig.Host, "host", envOrProfile("CFY_HOST", profile.Host, "localhost"),
 		"Manager host name or CFY_HOST in env")
 
-	var defaultUser = os.Getenv("CFY_USER")
-	if defaultUser == "" {
-		defaultUser = "admin"
-	}
-	commonFlagSet.StringVar(&cloudConfig.User, "user", defaultUser,
+	commonFlagSet.StringVar(&cloudConfig.User, "user", envOrProfile("CFY_USER", profile.User, "admin"),
 		"Manager user name or CFY_USER in env")
 
-	var defaultPassword = os.Getenv("CFY_PASSWORD")
-	if defaultPassword == "" {
-		defaultPassword = "admin"
-	}
-	commonFlagSet.StringVar(&cloudConfig.Password, "password", defaultPassword,
+	commonFlagSet.StringVar(&cloudConfig.Password, "password", envOrProfile("CFY_PASSWORD", profile.Password, "admin"),
 		"Manager user password or CFY_PASSWORD in env")
 
-	var defaultTenant = os.Getenv("CFY_TENANT")
-	if defaultTenant == "" {
-		defaultTenant = "default_tenant"
-	}
-	commonFlagSet.StringVar(&cloudConfig.Tenant, "tenant", defaultTenant,
+	commonFlagSet.StringVar(&cloudConfig.Tenant, "tenant", envOrProfile("CFY_TENANT", profile.Tenant, "default_tenant"),
 		"Manager tenant or CFY_TENANT in env")
 
-	var defaultAgent = os.Getenv("CFY_AGENT")
-	commonFlagSet.StringVar(&cloudConfig.AgentFile, "agent-file", defaultAgent,
+	commonFlagSet.StringVar(&cloudConfig.Token, "token", envOrProfile("CFY_TOKEN", profile.Token, ""),
+		"Manager api token, used instead of user/password, or CFY_TOKEN in env")
+
+	defaultSessionToken, err := strconv.ParseBool(os.Getenv("CFY_SESSION_TOKEN"))
+	if err != nil {
+		defaultSessionToken = profile.SessionToken
+	}
+	commonFlagSet.BoolVar(&cloudConfig.SessionToken, "session-token", defaultSessionToken,
+		"Get session token by user/password and use it for requests or CFY_SESSION_TOKEN in env")
+
+	commonFlagSet.StringVar(&cloudConfig.AgentFile, "agent-file", envOrProfile("CFY_AGENT", profile.AgentFile, ""),
 		"Cfy agent path or CFY_AGENT in env")
 
-	commonFlagSet.BoolVar(&cloudConfig.Debug, "debug", false,
+	commonFlagSet.BoolVar(&cloudConfig.Debug, "debug", profile.Debug,
 		"Manager debug or CFY_DEBUG in env")
 
+	defaultRetryAttempts, err := strconv.Atoi(os.Getenv("CFY_RETRY_ATTEMPTS"))
+	if err != nil {
+		// requests are not repeated by default
+		defaultRetryAttempts = 1
+		if profile.RetryAttempts > 0 {
+			defaultRetryAttempts = profile.RetryAttempts
+		}
+	}
+	commonFlagSet.IntVar(&cloudConfig.RetryAttempts, "retry-attempts", defaultRetryAttempts,
+		"Attempts count for requests failed by transient errors or CFY_RETRY_ATTEMPTS in env")
+
+	commonFlagSet.StringVar(&cloudConfig.CACertFile, "ca-cert", envOrProfile("CFY_CA_CERT", profile.CACertFile, ""),
+		"Manager CA certificates bundle path or CFY_CA_CERT in env")
+
+	commonFlagSet.StringVar(&cloudConfig.ClientCertFile, "client-cert", envOrProfile("CFY_CLIENT_CERT", profile.ClientCertFile, ""),
+		"Client certificate path or CFY_CLIENT_CERT in env")
+
+	commonFlagSet.StringVar(&cloudConfig.ClientKeyFile, "client-key", envOrProfile("CFY_CLIENT_KEY", profile.ClientKeyFile, ""),
+		"Client certificate key path or CFY_CLIENT_KEY in env")
+
+	commonFlagSet.StringVar(&cloudConfig.TLSServerName, "tls-server-name", envOrProfile("CFY_TLS_SERVER_NAME", profile.TLSServerName, ""),
+		"Manager server name for certificate check or CFY_TLS_SERVER_NAME in env")
+
+	defaultInsecure, err := strconv.ParseBool(os.Getenv("CFY_INSECURE"))
+	if err != nil {
+		defaultInsecure = profile.Insecure
+	}
+	commonFlagSet.BoolVar(&cloudConfig.Insecure, "insecure", defaultInsecure,
+		"Skip manager certificate check or CFY_INSECURE in env")
+
+	commonFlagSet.StringVar(&cloudConfig.CertFingerprint, "cert-fingerprint", envOrProfile("CFY_CERT_FINGERPRINT", profile.CertFingerprint, ""),
+		"Manager certificate sha256 fingerprint or CFY_CERT_FINGERPRINT in env")
+
+	// settings without command line options
+	cloudConfig.CACert = profile.CACert
+	cloudConfig.DeploymentsFile = profile.DeploymentsFile
+
+	addOutputOptions(commonFlagSet, profile.Output)
+
 	return commonFlagSet
 }
 
@@ -82,8 +120,16 @@ func getQuietClient() *cloudify.Client {
 /* getClient - return client that can show additional information for user */
 func getClient() *cloudify.Client {
 	cl := getQuietClient()
+	if !isTableOutput() {
+		// keep output machine readable
+		return cl
+	}
 	fmt.Printf("Manager: %v \n", cl.Host)
 	fmt.Printf("Api Version: %v\n", cl.GetAPIVersion())
+	if isTerminal(os.Stderr) {
+		bar := progressBar{output: os.Stderr}
+		cl.SetProgressHandler(bar.Update)
+	}
 	return cl
 }
 
@@ -92,6 +138,7 @@ func parsePagination(operFlagSet *flag.FlagSet, options []string) map[string]str
 	var pageOffset int
 	operFlagSet.IntVar(&pageSize, "size", 100, "Page size.")
 	operFlagSet.IntVar(&pageOffset, "offset", 0, "Page offset.")
+	operFlagSet.Bool("all", false, "Return all pages starting from offset.")
 	operFlagSet.Parse(options)
 
 	var params = map[string]string{}
@@ -101,6 +148,11 @@ func parsePagination(operFlagSet *flag.FlagSet, options []string) map[string]str
 	return params
 }
 
+func listAllRequested(operFlagSet *flag.FlagSet) bool {
+	allFlag := operFlagSet.Lookup("all")
+	return allFlag != nil && allFlag.Value.String() == "true"
+}
+
 //CommandInfo - storage for command name and callback
 type CommandInfo struct {
 	CommandName string
@@ -141,13 +193,20 @@ func main() {
 		"\tscaling-groups    Handle scale groups on the Manager\n" +
 		"\tevents            Show events from workflow executions\n" +
 		"\texecutions        Handle workflow executions\n" +
+		"\tinstall           Upload blueprint, create deployment and install it\n" +
+		"\tuninstall         Uninstall deployment and delete it with blueprint\n" +
 		"\tnode-instances    Handle a deployment's node-instances\n" +
 		"\tnodes             Handle a deployment's nodes\n" +
 		"\tplugins           Handle plugins on the manager\n" +
+		"\tsecrets           Handle secrets on the manager\n" +
+		"\tsnapshots         Handle manager snapshots\n" +
 		"\tstatus            Show manager status\n" +
 		"\tkubernetes        Additional kubernetes operations\n" +
 		"\tversion           Show client version\n" +
-		"\ttenants           Show tenants on the manager\n")
+		"\ttenants           Handle tenants on the manager\n" +
+		"\tusers             Handle users on the manager\n" +
+		"\tuser-groups       Handle user groups on the manager\n" +
+		"\tprofiles          Handle connection profiles\n")
 
 	if len(args) < 2 {
 		fmt.Println(defaultError)
@@ -180,10 +239,26 @@ func main() {
 		{
 			os.Exit(executionsOptions(args, options))
 		}
+	case "install":
+		{
+			os.Exit(installOptions(args, options))
+		}
+	case "uninstall":
+		{
+			os.Exit(uninstallOptions(args, options))
+		}
 	case "plugins":
 		{
 			os.Exit(pluginsOptions(args, options))
 		}
+	case "secrets":
+		{
+			os.Exit(secretsOptions(args, options))
+		}
+	case "snapshots":
+		{
+			os.Exit(snapshotsOptions(args, options))
+		}
 	case "events":
 		{
 			os.Exit(eventsOptions(args, options))
@@ -204,6 +279,18 @@ func main() {
 		{
 			os.Exit(tenantsOptions(args, options))
 		}
+	case "users":
+		{
+			os.Exit(usersOptions(args, options))
+		}
+	case "user-groups":
+		{
+			os.Exit(userGroupsOptions(args, options))
+		}
+	case "profiles":
+		{
+			os.Exit(profilesOptions(args, options))
+		}
 	case "container":
 		{
 			os.Exit(containerOptions(args, options))
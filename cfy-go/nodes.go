@@ -36,15 +36,23 @@ nodes - Handle a deployment's nodes
 
 		cfy-go nodes started -deployment deployment -node-type <nodeType>
 
+	graph - show relationships between deployment nodes as Graphviz DOT, use
+	-instances for graph of node instances and -order for show install order.
+	Json, yaml and csv outputs contain list of relationships.
+
+		cfy-go nodes graph -deployment deployment | dot -Tpng -o deployment.png
+		cfy-go nodes graph -deployment deployment -instances
+		cfy-go nodes graph -deployment deployment -order
+
 */
 package main
 
 import (
 	"fmt"
 	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
-	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 	"log"
 	"os"
+	"strings"
 )
 
 func nodesGroupPrint(nodes *cloudify.NodeWithGroups, err error) int {
@@ -53,20 +61,25 @@ func nodesGroupPrint(nodes *cloudify.NodeWithGroups, err error) int {
 		return 1
 	}
 
-	lines := make([][]string, len(nodes.Items))
-	for pos, node := range nodes.Items {
-		lines[pos] = make([]string, 6)
-		lines[pos][0] = node.ID
-		lines[pos][1] = node.DeploymentID
-		lines[pos][2] = node.HostID
-		lines[pos][3] = node.Type
-		lines[pos][4] = node.GroupName
-		lines[pos][5] = node.ScalingGroupName
+	res := printOutput(nodes.Items, func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(nodes.Items))
+		for pos, node := range nodes.Items {
+			lines[pos] = make([]string, 6)
+			lines[pos][0] = node.ID
+			lines[pos][1] = node.DeploymentID
+			lines[pos][2] = node.HostID
+			lines[pos][3] = node.Type
+			lines[pos][4] = node.GroupName
+			lines[pos][5] = node.ScalingGroupName
+		}
+		return []string{
+			"Id", "Deployment id", "Host id", "Type", "Group", "Scaling Group",
+		}, lines
+	})
+	if res != 0 || !isTableOutput() {
+		return res
 	}
-	utils.PrintTable([]string{
-		"Id", "Deployment id", "Host id", "Type", "Group", "Scaling Group",
-	}, lines)
-	fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
 		nodes.Metadata.Pagination.Offset, len(nodes.Items),
 		nodes.Metadata.Pagination.Total)
 	return 0
@@ -78,25 +91,41 @@ func nodesPrint(nodes *cloudify.Nodes, err error) int {
 		return 1
 	}
 
-	lines := make([][]string, len(nodes.Items))
-	for pos, node := range nodes.Items {
-		lines[pos] = make([]string, 9)
-		lines[pos][0] = node.ID
-		lines[pos][1] = node.DeploymentID
-		lines[pos][2] = node.BlueprintID
-		lines[pos][3] = node.HostID
-		lines[pos][4] = node.Type
-		lines[pos][5] = fmt.Sprintf("%d", node.NumberOfInstances)
-		lines[pos][6] = fmt.Sprintf("%d", node.PlannedNumberOfInstances)
-		lines[pos][7] = node.Tenant
-		lines[pos][8] = node.CreatedBy
+	res := printOutput(nodes.Items, func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(nodes.Items))
+		for pos, node := range nodes.Items {
+			lines[pos] = make([]string, 9)
+			lines[pos][0] = node.ID
+			lines[pos][1] = node.DeploymentID
+			lines[pos][2] = node.BlueprintID
+			lines[pos][3] = node.HostID
+			lines[pos][4] = node.Type
+			lines[pos][5] = fmt.Sprintf("%d", node.NumberOfInstances)
+			lines[pos][6] = fmt.Sprintf("%d", node.PlannedNumberOfInstances)
+			lines[pos][7] = node.Tenant
+			lines[pos][8] = node.CreatedBy
+			if wide {
+				properties, err := node.GetJSONProperties()
+				if err != nil {
+					log.Printf("Cloudify error: %s\n", err.Error())
+				}
+				lines[pos] = append(lines[pos], properties)
+			}
+		}
+		titles := []string{
+			"Id", "Deployment id", "Blueprint id", "Host id", "Type",
+			"Number of instances", "Planned number of instances",
+			"Tenant", "created_by",
+		}
+		if wide {
+			titles = append(titles, "Properties")
+		}
+		return titles, lines
+	})
+	if res != 0 || !isTableOutput() {
+		return res
 	}
-	utils.PrintTable([]string{
-		"Id", "Deployment id", "Blueprint id", "Host id", "Type",
-		"Number of instances", "Planned number of instances",
-		"Tenant", "created_by",
-	}, lines)
-	fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
 		nodes.Metadata.Pagination.Offset, len(nodes.Items),
 		nodes.Metadata.Pagination.Total)
 	if len(nodes.Items) == 1 {
@@ -112,8 +141,90 @@ func nodesPrint(nodes *cloudify.Nodes, err error) int {
 	return 0
 }
 
+func topologyRelationships(topology *cloudify.Topology) []cloudify.TopologyRelationship {
+	relationships := []cloudify.TopologyRelationship{}
+	for _, id := range topology.IDs() {
+		relationships = append(relationships, topology.Relationships(id)...)
+	}
+	return relationships
+}
+
+func topologyOrderPrint(topology *cloudify.Topology) int {
+	order, err := topology.InstallOrder()
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printOutput(order, func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(order))
+		for pos, id := range order {
+			lines[pos] = []string{
+				fmt.Sprintf("%d", pos+1), id,
+				strings.Join(topology.Dependencies(id), ", "),
+				strings.Join(topology.ContainedIn(id), " -> "),
+			}
+		}
+		return []string{"Order", "Id", "Dependencies", "Contained in"}, lines
+	})
+}
+
+func nodesGraph(args, options []string) int {
+	operFlagSet := basicOptions("nodes graph")
+	var deployment string
+	var instances bool
+	var order bool
+	operFlagSet.StringVar(&deployment, "deployment", "",
+		"The unique identifier for the deployment")
+	operFlagSet.BoolVar(&instances, "instances", false,
+		"Show graph of node instances")
+	operFlagSet.BoolVar(&order, "order", false,
+		"Show install order instead of graph")
+	operFlagSet.Parse(options)
+
+	if deployment == "" {
+		fmt.Println("Please provide deployment")
+		return 1
+	}
+
+	var params = map[string]string{}
+	params["deployment_id"] = deployment
+
+	// without manager information, graph can be piped to graphviz
+	cl := getQuietClient()
+	nodes, err := cl.GetAllNodes(params)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	topology := cloudify.NewNodesTopology(nodes.Items)
+	if instances {
+		nodeInstances, err := cl.GetAllNodeInstances(params)
+		if err != nil {
+			log.Printf("Cloudify error: %s\n", err.Error())
+			return 1
+		}
+		topology = cloudify.NewNodeInstancesTopology(nodeInstances.Items, nodes.Items)
+	}
+
+	if order {
+		return topologyOrderPrint(topology)
+	}
+	if !isTableOutput() {
+		relationships := topologyRelationships(topology)
+		return printOutput(relationships, func(wide bool) ([]string, [][]string) {
+			lines := make([][]string, len(relationships))
+			for pos, rel := range relationships {
+				lines[pos] = []string{rel.SourceID, rel.TargetID, rel.Type}
+			}
+			return []string{"source_id", "target_id", "type"}, lines
+		})
+	}
+	fmt.Print(topology.DOT(deployment))
+	return 0
+}
+
 func nodesOptions(args, options []string) int {
-	defaultError := "list/group/started subcommand is required"
+	defaultError := "list/group/started/graph subcommand is required"
 
 	if len(args) < 3 {
 		fmt.Println(defaultError)
@@ -121,6 +232,10 @@ func nodesOptions(args, options []string) int {
 	}
 
 	switch args[2] {
+	case "graph":
+		{
+			return nodesGraph(args, options)
+		}
 	case "started":
 		{
 			operFlagSet := basicOptions("nodes started")
@@ -227,7 +342,11 @@ func nodesOptions(args, options []string) int {
 			}
 
 			cl := getClient()
-			return nodesPrint(cl.GetNodes(params))
+			getNodes := cl.GetNodes
+			if listAllRequested(operFlagSet) {
+				getNodes = cl.GetAllNodes
+			}
+			return nodesPrint(getNodes(params))
 		}
 	default:
 		{
@@ -235,5 +354,4 @@ func nodesOptions(args, options []string) int {
 			return 1
 		}
 	}
-	return 0
 }
@@ -0,0 +1,287 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Output
+
+All commands support common options for select output format:
+
+	-output string
+		Output format: table, wide, json, yaml or csv, or CFY_OUTPUT in env (default "table")
+	-jsonpath string
+		Print only fields selected by path from json output, e.g. '{[*].id}'
+	-template string
+		Print json output fields by go template, e.g. '{{range .}}{{.id}}{{"\n"}}{{end}}'
+
+Table output is same as before, wide output adds additional columns to table,
+csv output contains all columns from wide output. Json and yaml outputs
+contain full objects returned by manager with same field names as in
+manager api, path and template use same names. List commands return list
+of objects without pagination information.
+
+	cfy-go deployments list -output json
+	cfy-go nodes list -deployment deployment -jsonpath '{[*].id}'
+	cfy-go node-instances list -deployment deployment -jsonpath '{[*].runtime_properties["cloudify.kubernetes"]}'
+
+Table output on terminal also shows progress of blueprint, plugin and
+snapshot uploads and downloads.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
+	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
+	"gopkg.in/yaml.v2"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Supported output formats
+const (
+	outputTable = "table"
+	outputWide  = "wide"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputCSV   = "csv"
+)
+
+var outputConfig struct {
+	format   string
+	jsonPath string
+	template string
+}
+
+// tableBuilder - return table columns for result, wide adds additional columns
+type tableBuilder func(wide bool) (titles []string, lines [][]string)
+
+func addOutputOptions(commonFlagSet *flag.FlagSet, profileOutput string) {
+	commonFlagSet.StringVar(&outputConfig.format, "output", envOrProfile("CFY_OUTPUT", profileOutput, outputTable),
+		"Output format: table, wide, json, yaml or csv, or CFY_OUTPUT in env")
+	commonFlagSet.StringVar(&outputConfig.jsonPath, "jsonpath", "",
+		"Print only fields selected by path from json output, e.g. '{[*].id}'")
+	commonFlagSet.StringVar(&outputConfig.template, "template", "",
+		"Print json output fields by go template")
+}
+
+// groupedTable - join tables of several groups to one table with group column
+func groupedTable(groupTitle string, groupNames []string, groupTable func(groupName string) tableBuilder) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		titles, _ := groupTable("")(wide)
+		lines := [][]string{}
+		sort.Strings(groupNames)
+		for _, groupName := range groupNames {
+			_, groupLines := groupTable(groupName)(wide)
+			for _, line := range groupLines {
+				lines = append(lines, append([]string{groupName}, line...))
+			}
+		}
+		return append([]string{groupTitle}, titles...), lines
+	}
+}
+
+// isTableOutput - output is for human, additional messages can be printed
+func isTableOutput() bool {
+	return outputConfig.jsonPath == "" && outputConfig.template == "" &&
+		(outputConfig.format == outputTable || outputConfig.format == outputWide)
+}
+
+// printOutput - print result in format selected by user
+func printOutput(data interface{}, table tableBuilder) int {
+	var err error
+	switch {
+	case outputConfig.jsonPath != "":
+		err = printJSONPath(data, outputConfig.jsonPath)
+	case outputConfig.template != "":
+		err = printTemplate(data, outputConfig.template)
+	case outputConfig.format == outputTable:
+		utils.PrintTable(table(false))
+	case outputConfig.format == outputWide:
+		utils.PrintTable(table(true))
+	case outputConfig.format == outputJSON:
+		err = printJSON(data)
+	case outputConfig.format == outputYAML:
+		err = printYAML(data)
+	case outputConfig.format == outputCSV:
+		err = printCSV(table(true))
+	default:
+		fmt.Printf("Unknown output format %s, use table, wide, json, yaml or csv\n",
+			outputConfig.format)
+		return 1
+	}
+	if err != nil {
+		log.Printf("Output error: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
+
+func printJSON(data interface{}) error {
+	jsonData, err := json.MarshalIndent(data, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(jsonData))
+	return nil
+}
+
+func printYAML(data interface{}) error {
+	// convert to json first for use same field names as in json
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal(jsonData, &generic); err != nil {
+		return err
+	}
+	yamlData, err := yaml.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(yamlData))
+	return nil
+}
+
+func printCSV(titles []string, lines [][]string) error {
+	writer := csv.NewWriter(os.Stdout)
+	if err := writer.Write(titles); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(lines); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// genericData - convert typed result to maps and lists with json field names
+func genericData(data interface{}) (interface{}, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+func printTemplate(data interface{}, text string) error {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return err
+	}
+	generic, err := genericData(data)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, generic); err != nil {
+		return err
+	}
+	fmt.Println(buf.String())
+	return nil
+}
+
+// splitJSONPath - split path like '{.items[*].id}' to keys: items, *, id
+func splitJSONPath(path string) ([]string, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "{")
+	path = strings.TrimSuffix(path, "}")
+	return cloudify.SplitPropertyPath(path)
+}
+
+// selectJSONPath - return all values selected by path keys
+func selectJSONPath(values []interface{}, keys []string) ([]interface{}, error) {
+	for _, key := range keys {
+		selected := []interface{}{}
+		for _, value := range values {
+			switch typed := value.(type) {
+			case map[string]interface{}:
+				if key == "*" {
+					// map keys order is random, use sorted keys for stable output
+					names := make([]string, 0, len(typed))
+					for name := range typed {
+						names = append(names, name)
+					}
+					sort.Strings(names)
+					for _, name := range names {
+						selected = append(selected, typed[name])
+					}
+				} else if item, ok := typed[key]; ok {
+					selected = append(selected, item)
+				}
+			case []interface{}:
+				if key == "*" {
+					selected = append(selected, typed...)
+					continue
+				}
+				pos, err := strconv.Atoi(key)
+				if err != nil {
+					return nil, fmt.Errorf("list index expected, got %s", key)
+				}
+				if pos < 0 {
+					pos += len(typed)
+				}
+				if pos >= 0 && pos < len(typed) {
+					selected = append(selected, typed[pos])
+				}
+			}
+		}
+		values = selected
+	}
+	return values, nil
+}
+
+func printJSONPath(data interface{}, path string) error {
+	generic, err := genericData(data)
+	if err != nil {
+		return err
+	}
+	keys, err := splitJSONPath(path)
+	if err != nil {
+		return err
+	}
+	values, err := selectJSONPath([]interface{}{generic}, keys)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		if text, ok := value.(string); ok {
+			fmt.Println(text)
+			continue
+		}
+		jsonData, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonData))
+	}
+	return nil
+}
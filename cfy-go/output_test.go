@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
+)
+
+func Example_printOutput() {
+	var tenants = []cloudify.Tenant{{Name: "default_tenant", Users: 1}, {Name: "dev, qa"}}
+
+	outputConfig.format = outputCSV
+	printTenants(tenants)
+
+	outputConfig.format = outputJSON
+	outputConfig.jsonPath = "{[*].name}"
+	printTenants(tenants)
+
+	outputConfig.jsonPath = ""
+	outputConfig.template = `{{range .}}{{.name}}={{.users}};{{end}}`
+	printTenants(tenants)
+
+	outputConfig.template = ""
+	outputConfig.format = outputTable
+	// Output: name,users,groups
+	// default_tenant,1,0
+	// "dev, qa",0,0
+	// default_tenant
+	// dev, qa
+	// default_tenant=1;dev, qa=0;
+}
+
+func Example_printJSONPath() {
+	printJSONPath(map[string]interface{}{
+		"zone": "b", "name": "vm", "ip": "10.0.0.1", "labels": map[string]int{"b": 2, "a": 1},
+	}, "{.*}")
+	// Output: 10.0.0.1
+	// {"a":1,"b":2}
+	// vm
+	// b
+}
+
+func Example_printJSONPathQuoted() {
+	printJSONPath(map[string]interface{}{
+		"cloudify.kubernetes": map[string]string{"url": "https://10.0.0.1:6443"},
+	}, `{["cloudify.kubernetes"].url}`)
+	// Output: https://10.0.0.1:6443
+}
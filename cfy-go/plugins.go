@@ -29,15 +29,29 @@ plugins - Handle plugins on the manager
 
 	get: Retrieve plugin information [manager only].
 
-		cfy-go plugins list -plugin-id <plugin-id>
+		cfy-go plugins get <plugin-id>
+
+	inspect: Show wagon metadata and check plugin yaml without manager.
+
+		cfy-go plugins inspect -plugin-path <plugin-path>.wgn -yaml-path <yaml-path>.yaml
 
 	list: List plugins [manager only]
 
 		cfy-go plugins list
 
-	upload: Upload a plugin [manager only].
+	update: Update deployments of blueprint to latest plugins versions [manager only].
+
+		cfy-go plugins update <blueprint-id> -wait
+
+	updates: List plugins updates [manager only].
+
+		cfy-go plugins updates -blueprint <blueprint-id>
+
+	upload: Upload a plugin [manager only]. Plugin yaml is checked against
+	wagon metadata before upload.
 
 		cfy-go plugins upload -host 172.16.168.176 -plugin-path <plugin-path>.wgn -yaml-path <yaml-path>.yaml
+		cfy-go plugins upload -url <wagon-url>
 
 */
 package main
@@ -46,33 +60,41 @@ import (
 	"flag"
 	"fmt"
 	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
-	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 	"log"
+	"strings"
 )
 
-func printPlugins(plugins []cloudify.Plugin) {
-	lines := make([][]string, len(plugins))
-	for pos, plugin := range plugins {
-		lines[pos] = make([]string, 9)
-		lines[pos][0] = plugin.ID
-		lines[pos][1] = plugin.PackageName
-		lines[pos][2] = plugin.PackageVersion
-		lines[pos][3] = plugin.Distribution
-		lines[pos][4] = plugin.SupportedPlatform
-		lines[pos][5] = plugin.DistributionRelease
-		lines[pos][6] = plugin.UploadedAt
-		lines[pos][7] = plugin.Tenant
-		lines[pos][8] = plugin.CreatedBy
-	}
-	utils.PrintTable([]string{
-		"Id", "Package name", "Package version", "Distribution",
-		"Supported platform", "Distribution release", "Uploaded at",
-		"Tenant", "Created by",
-	}, lines)
+func pluginsTable(plugins []cloudify.Plugin) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(plugins))
+		for pos, plugin := range plugins {
+			lines[pos] = make([]string, 9)
+			lines[pos][0] = plugin.ID
+			lines[pos][1] = plugin.PackageName
+			lines[pos][2] = plugin.PackageVersion
+			lines[pos][3] = plugin.Distribution
+			lines[pos][4] = plugin.SupportedPlatform
+			lines[pos][5] = plugin.DistributionRelease
+			lines[pos][6] = plugin.UploadedAt
+			lines[pos][7] = plugin.Tenant
+			lines[pos][8] = plugin.CreatedBy
+		}
+		return []string{
+			"Id", "Package name", "Package version", "Distribution",
+			"Supported platform", "Distribution release", "Uploaded at",
+			"Tenant", "Created by",
+		}, lines
+	}
+}
 
+func printPlugins(plugins []cloudify.Plugin) int {
+	return printOutput(plugins, pluginsTable(plugins))
 }
 
 func uploadPluginsCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	var pluginURL string
+	operFlagSet.StringVar(&pluginURL, "url", "",
+		"The plugin wagon url")
 	var pluginPath string
 	operFlagSet.StringVar(&pluginPath, "plugin-path", "",
 		"The plugin path")
@@ -83,11 +105,11 @@ func uploadPluginsCall(operFlagSet *flag.FlagSet, args, options []string) int {
 	operFlagSet.StringVar(&visibility, "visibility", "tenant",
 		"The plugin visibility")
 	operFlagSet.Parse(options)
-	if len(pluginPath) < 4 {
-		fmt.Println("Plugin path required")
+	if pluginURL == "" && len(pluginPath) < 4 {
+		fmt.Println("Plugin path or url required")
 		return 1
 	}
-	if len(yamlPath) < 4 {
+	if pluginURL == "" && len(yamlPath) < 4 {
 		fmt.Println("Plugin yaml file required")
 		return 1
 	}
@@ -95,13 +117,18 @@ func uploadPluginsCall(operFlagSet *flag.FlagSet, args, options []string) int {
 	var params = map[string]string{}
 	params["visibility"] = visibility
 	cl := getClient()
-	plugin, err := cl.UploadPlugin(params, pluginPath, yamlPath)
+	var plugin *cloudify.PluginGet
+	var err error
+	if pluginURL != "" {
+		plugin, err = cl.UploadPluginURL(params, pluginURL)
+	} else {
+		plugin, err = cl.UploadPlugin(params, pluginPath, yamlPath)
+	}
 	if err != nil {
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
-	printPlugins([]cloudify.Plugin{plugin.Plugin})
-	return 0
+	return printOutput(plugin.Plugin, pluginsTable([]cloudify.Plugin{plugin.Plugin}))
 }
 
 func deletePluginsCall(operFlagSet *flag.FlagSet, args, options []string) int {
@@ -125,8 +152,7 @@ func deletePluginsCall(operFlagSet *flag.FlagSet, args, options []string) int {
 		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
-	printPlugins([]cloudify.Plugin{plugin.Plugin})
-	return 0
+	return printOutput(plugin.Plugin, pluginsTable([]cloudify.Plugin{plugin.Plugin}))
 }
 
 func downloadPluginsCall(operFlagSet *flag.FlagSet, args, options []string) int {
@@ -163,19 +189,182 @@ func listPluginsCall(operFlagSet *flag.FlagSet, args, options []string) int {
 	}
 
 	cl := getClient()
-	plugins, err := cl.GetPlugins(params)
+	getPlugins := cl.GetPlugins
+	if listAllRequested(operFlagSet) {
+		getPlugins = cl.GetAllPlugins
+	}
+	plugins, err := getPlugins(params)
 	if err != nil {
 		log.Printf("Cloudify error: %s", err.Error())
 		return 1
 	}
-	printPlugins(plugins.Items)
-	fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
+	if res := printPlugins(plugins.Items); res != 0 || !isTableOutput() {
+		return res
+	}
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
 		plugins.Metadata.Pagination.Offset, len(plugins.Items),
 		plugins.Metadata.Pagination.Total)
 
 	return 0
 }
 
+func getPluginCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Plugin Id required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	plugin, err := cl.GetPlugin(args[3])
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printPlugins([]cloudify.Plugin{*plugin})
+}
+
+func inspectPluginCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	var pluginPath string
+	operFlagSet.StringVar(&pluginPath, "plugin-path", "",
+		"The plugin path")
+	var yamlPath string
+	operFlagSet.StringVar(&yamlPath, "yaml-path", "",
+		"The plugin yaml path, checked against wagon if set")
+	operFlagSet.Parse(options)
+
+	if len(pluginPath) < 4 {
+		fmt.Println("Plugin path required")
+		return 1
+	}
+
+	wagon, err := cloudify.InspectWagon(pluginPath)
+	if err != nil {
+		fmt.Printf("Wagon error: %s\n", err.Error())
+		return 1
+	}
+	res := printOutput(wagon, func(wide bool) ([]string, [][]string) {
+		return []string{"setting", "value"}, [][]string{
+			{"package_name", wagon.PackageName},
+			{"package_version", wagon.PackageVersion},
+			{"supported_platform", wagon.SupportedPlatform},
+			{"supported_python_versions", strings.Join(wagon.SupportedPythonVersions, ", ")},
+			{"distribution", wagon.BuildServerOSProperties.Distribution},
+			{"distribution_version", wagon.BuildServerOSProperties.DistributionVersion},
+			{"distribution_release", wagon.BuildServerOSProperties.DistributionRelease},
+			{"archive_name", wagon.ArchiveName},
+			{"wheels", strings.Join(wagon.Wheels, ", ")},
+		}
+	})
+	if res != 0 || yamlPath == "" {
+		return res
+	}
+	if err := wagon.CheckPluginYaml(yamlPath); err != nil {
+		fmt.Printf("Plugin yaml error: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
+
+func pluginsUpdatesTable(updates []cloudify.PluginsUpdate) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(updates))
+		for pos, update := range updates {
+			lines[pos] = make([]string, 6)
+			lines[pos][0] = update.ID
+			lines[pos][1] = update.BlueprintID
+			lines[pos][2] = update.State
+			lines[pos][3] = strings.Join(update.DeploymentsToUpdate, ", ")
+			lines[pos][4] = update.ExecutionID
+			lines[pos][5] = update.CreatedAt
+			if wide {
+				lines[pos] = append(lines[pos], update.TempBlueprintID,
+					fmt.Sprintf("%v", update.Forced))
+			}
+		}
+		titles := []string{
+			"id", "blueprint_id", "state", "deployments_to_update",
+			"execution_id", "created_at",
+		}
+		if wide {
+			titles = append(titles, "temp_blueprint_id", "forced")
+		}
+		return titles, lines
+	}
+}
+
+func printPluginsUpdates(updates []cloudify.PluginsUpdate) int {
+	return printOutput(updates, pluginsUpdatesTable(updates))
+}
+
+func updatePluginsCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Blueprint Id required")
+		return 1
+	}
+
+	var update cloudify.PluginsUpdatePost
+	var wait bool
+	operFlagSet.BoolVar(&update.Force, "force", false,
+		"Start update even if previous update is not finished")
+	operFlagSet.BoolVar(&wait, "wait", false,
+		"Wait until update finished")
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	started, err := cl.UpdatePlugins(args[3], update)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if !wait {
+		return printPluginsUpdates([]cloudify.PluginsUpdate{*started})
+	}
+	if isTableOutput() {
+		printPluginsUpdates([]cloudify.PluginsUpdate{*started})
+	}
+
+	result, err := cl.WaitPluginsUpdate(started.ID)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if res := printPluginsUpdates([]cloudify.PluginsUpdate{result.Update}); res != 0 {
+		return res
+	}
+	if result.State != cloudify.WaitTerminated {
+		log.Printf("Plugins update %s %s\n", result.Update.ID, result.State)
+		return 1
+	}
+	return 0
+}
+
+func listPluginsUpdatesCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	var blueprint string
+	operFlagSet.StringVar(&blueprint, "blueprint", "",
+		"The unique identifier for the blueprint")
+
+	params := parsePagination(operFlagSet, options)
+
+	if blueprint != "" {
+		params["blueprint_id"] = blueprint
+	}
+
+	cl := getClient()
+	updates, err := cl.GetPluginsUpdates(params)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if res := printPluginsUpdates(updates.Items); res != 0 || !isTableOutput() {
+		return res
+	}
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
+		updates.Metadata.Pagination.Offset, len(updates.Items),
+		updates.Metadata.Pagination.Total)
+	return 0
+}
+
 func pluginsOptions(args, options []string) int {
 	var pluginsCalls = []CommandInfo{{
 		CommandName: "list",
@@ -189,6 +378,18 @@ func pluginsOptions(args, options []string) int {
 	}, {
 		CommandName: "delete",
 		Callback:    deletePluginsCall,
+	}, {
+		CommandName: "get",
+		Callback:    getPluginCall,
+	}, {
+		CommandName: "inspect",
+		Callback:    inspectPluginCall,
+	}, {
+		CommandName: "update",
+		Callback:    updatePluginsCall,
+	}, {
+		CommandName: "updates",
+		Callback:    listPluginsUpdatesCall,
 	}}
 
 	return ParseCalls(pluginsCalls, 3, args, options)
@@ -0,0 +1,413 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Profiles
+
+Connection settings can be saved as named profiles in ~/.cfy-go/config.yaml
+or in file set by CFY_CONFIG in env. Profile keys have same names as common
+options:
+
+	current: prod
+	profiles:
+	  prod:
+	    host: manager.example.com
+	    user: admin
+	    password: secret
+	    tenant: default_tenant
+	    ca-cert: /etc/cloudify/ca.crt
+	    output: json
+
+Profile is selected by -profile option, CFY_PROFILE in env or by current
+profile from config file. Each option value is taken from command line
+option first, then from CFY_* in env, then from profile and default value is
+used only if value is not set anywhere.
+
+profiles - Handle connection profiles
+
+	create - Create profile from common options, first profile is used as current.
+
+		cfy-go profiles create prod -host manager.example.com -user admin -password secret
+
+	delete - Delete profile.
+
+		cfy-go profiles delete prod
+
+	list - List profiles.
+
+		cfy-go profiles list
+
+	show - Show profile settings, current profile is used without name.
+
+		cfy-go profiles show prod
+
+	use - Set current profile.
+
+		cfy-go profiles use prod
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// cfyProfile - connection settings saved in config file, keys are same as
+// names of common options
+type cfyProfile struct {
+	Host            string `json:"host,omitempty" yaml:"host,omitempty"`
+	User            string `json:"user,omitempty" yaml:"user,omitempty"`
+	Password        string `json:"password,omitempty" yaml:"password,omitempty"`
+	Tenant          string `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+	Token           string `json:"token,omitempty" yaml:"token,omitempty"`
+	SessionToken    bool   `json:"session-token,omitempty" yaml:"session-token,omitempty"`
+	AgentFile       string `json:"agent-file,omitempty" yaml:"agent-file,omitempty"`
+	DeploymentsFile string `json:"deployments-file,omitempty" yaml:"deployments-file,omitempty"`
+	Debug           bool   `json:"debug,omitempty" yaml:"debug,omitempty"`
+	RetryAttempts   int    `json:"retry-attempts,omitempty" yaml:"retry-attempts,omitempty"`
+	CACertFile      string `json:"ca-cert,omitempty" yaml:"ca-cert,omitempty"`
+	// CA certificates bundle in PEM format, used instead of file
+	CACert          string `json:"ca-cert-data,omitempty" yaml:"ca-cert-data,omitempty"`
+	ClientCertFile  string `json:"client-cert,omitempty" yaml:"client-cert,omitempty"`
+	ClientKeyFile   string `json:"client-key,omitempty" yaml:"client-key,omitempty"`
+	TLSServerName   string `json:"tls-server-name,omitempty" yaml:"tls-server-name,omitempty"`
+	Insecure        bool   `json:"insecure,omitempty" yaml:"insecure,omitempty"`
+	CertFingerprint string `json:"cert-fingerprint,omitempty" yaml:"cert-fingerprint,omitempty"`
+	Output          string `json:"output,omitempty" yaml:"output,omitempty"`
+}
+
+// cfyConfig - content of config file
+type cfyConfig struct {
+	Current  string                `yaml:"current,omitempty"`
+	Profiles map[string]cfyProfile `yaml:"profiles"`
+}
+
+func configPath() string {
+	if path := os.Getenv("CFY_CONFIG"); path != "" {
+		return path
+	}
+	return filepath.Join(os.Getenv("HOME"), ".cfy-go", "config.yaml")
+}
+
+// loadConfig - read config file, empty config is returned if file does not exist
+func loadConfig() (*cfyConfig, error) {
+	var config cfyConfig
+	content, err := ioutil.ReadFile(configPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := yaml.Unmarshal(content, &config); err != nil {
+			return nil, fmt.Errorf("can't parse %s: %s", configPath(), err.Error())
+		}
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]cfyProfile{}
+	}
+	return &config, nil
+}
+
+// saveConfig - write config file readable only by user, file contains passwords
+func saveConfig(config *cfyConfig) error {
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	path := configPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0600)
+}
+
+// selectedProfileName - profile name from -profile option or CFY_PROFILE in env,
+// option is checked before parse because profile values are used as defaults
+// for other options
+func selectedProfileName(args []string) string {
+	for pos, arg := range args {
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			continue
+		}
+		if name == "profile" && pos+1 < len(args) {
+			return args[pos+1]
+		}
+		if strings.HasPrefix(name, "profile=") {
+			return strings.TrimPrefix(name, "profile=")
+		}
+	}
+	return os.Getenv("CFY_PROFILE")
+}
+
+// currentProfile - profile selected by user or current profile from config
+func currentProfile() cfyProfile {
+	config, err := loadConfig()
+	if err != nil {
+		log.Printf("Profiles are not loaded: %s\n", err.Error())
+		return cfyProfile{}
+	}
+
+	name := selectedProfileName(os.Args[1:])
+	if name == "" {
+		name = config.Current
+		if name == "" {
+			return cfyProfile{}
+		}
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		fmt.Printf("Profile %s is not found in %s\n", name, configPath())
+		os.Exit(1)
+	}
+	return profile
+}
+
+// envOrProfile - value from env, profile or default value
+func envOrProfile(envName, profileValue, defaultValue string) string {
+	if value := os.Getenv(envName); value != "" {
+		return value
+	}
+	if profileValue != "" {
+		return profileValue
+	}
+	return defaultValue
+}
+
+// hidePassword - hide value if it is set
+func hidePassword(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "***"
+}
+
+func profilesTable(config *cfyConfig, names []string) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(names))
+		for pos, name := range names {
+			profile := config.Profiles[name]
+			current := ""
+			if name == config.Current {
+				current = "*"
+			}
+			lines[pos] = []string{current, name, profile.Host, profile.User, profile.Tenant}
+			if wide {
+				lines[pos] = append(lines[pos], profile.CACertFile,
+					fmt.Sprintf("%v", profile.Insecure), profile.Output)
+			}
+		}
+		titles := []string{"current", "name", "host", "user", "tenant"}
+		if wide {
+			titles = append(titles, "ca_cert", "insecure", "output")
+		}
+		return titles, lines
+	}
+}
+
+func listProfilesCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	operFlagSet.Parse(options)
+
+	config, err := loadConfig()
+	if err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+	names := []string{}
+	for name, profile := range config.Profiles {
+		names = append(names, name)
+		// don't show credentials in machine readable output
+		profile.Password = hidePassword(profile.Password)
+		profile.Token = hidePassword(profile.Token)
+		config.Profiles[name] = profile
+	}
+	sort.Strings(names)
+	return printOutput(config.Profiles, profilesTable(config, names))
+}
+
+func showProfileCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	operFlagSet.Parse(options)
+
+	config, err := loadConfig()
+	if err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+	name := config.Current
+	if len(args) >= 4 {
+		name = args[3]
+	}
+	profile, ok := config.Profiles[name]
+	if !ok {
+		fmt.Printf("Profile %s is not found\n", name)
+		return 1
+	}
+	profile.Password = hidePassword(profile.Password)
+	profile.Token = hidePassword(profile.Token)
+
+	return printOutput(profile, func(wide bool) ([]string, [][]string) {
+		content, err := yaml.Marshal(profile)
+		if err != nil {
+			log.Printf("Profiles error: %s\n", err.Error())
+		}
+		var values = yaml.MapSlice{}
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			log.Printf("Profiles error: %s\n", err.Error())
+		}
+		lines := [][]string{{"name", name}}
+		for _, value := range values {
+			lines = append(lines, []string{
+				fmt.Sprintf("%v", value.Key), fmt.Sprintf("%v", value.Value),
+			})
+		}
+		return []string{"setting", "value"}, lines
+	})
+}
+
+func createProfileCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Profile name required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	config, err := loadConfig()
+	if err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+	name := args[3]
+	if _, ok := config.Profiles[name]; ok {
+		fmt.Printf("Profile %s already exists, delete it before create\n", name)
+		return 1
+	}
+
+	// save only options set in command line, as yaml with same keys
+	var values = map[string]interface{}{}
+	operFlagSet.Visit(func(option *flag.Flag) {
+		if option.Name == "profile" {
+			return
+		}
+		if getter, ok := option.Value.(flag.Getter); ok {
+			values[option.Name] = getter.Get()
+		}
+	})
+	content, err := yaml.Marshal(values)
+	if err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+	var profile cfyProfile
+	if err := yaml.Unmarshal(content, &profile); err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+
+	config.Profiles[name] = profile
+	if config.Current == "" {
+		config.Current = name
+	}
+	if err := saveConfig(config); err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+	fmt.Printf("Profile %s saved to %s\n", name, configPath())
+	return 0
+}
+
+func deleteProfileCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Profile name required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	config, err := loadConfig()
+	if err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+	name := args[3]
+	if _, ok := config.Profiles[name]; !ok {
+		fmt.Printf("Profile %s is not found\n", name)
+		return 1
+	}
+	delete(config.Profiles, name)
+	if config.Current == name {
+		config.Current = ""
+	}
+	if err := saveConfig(config); err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+	fmt.Printf("Profile %s deleted\n", name)
+	return 0
+}
+
+func useProfileCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Profile name required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	config, err := loadConfig()
+	if err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+	name := args[3]
+	if _, ok := config.Profiles[name]; !ok {
+		fmt.Printf("Profile %s is not found\n", name)
+		return 1
+	}
+	config.Current = name
+	if err := saveConfig(config); err != nil {
+		log.Printf("Profiles error: %s\n", err.Error())
+		return 1
+	}
+	fmt.Printf("Profile %s is used as current\n", name)
+	return 0
+}
+
+func profilesOptions(args, options []string) int {
+	var profilesCalls = []CommandInfo{{
+		CommandName: "list",
+		Callback:    listProfilesCall,
+	}, {
+		CommandName: "show",
+		Callback:    showProfileCall,
+	}, {
+		CommandName: "create",
+		Callback:    createProfileCall,
+	}, {
+		CommandName: "delete",
+		Callback:    deleteProfileCall,
+	}, {
+		CommandName: "use",
+		Callback:    useProfileCall,
+	}}
+
+	return ParseCalls(profilesCalls, 3, args, options)
+}
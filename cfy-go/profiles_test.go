@@ -0,0 +1,45 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func Example_selectedProfileName() {
+	os.Setenv("CFY_PROFILE", "env")
+	defer os.Unsetenv("CFY_PROFILE")
+
+	fmt.Println(selectedProfileName([]string{"status", "-profile", "prod"}))
+	fmt.Println(selectedProfileName([]string{"status", "--profile=dev", "-host", "localhost"}))
+	fmt.Println(selectedProfileName([]string{"status", "-host", "localhost"}))
+	// Output: prod
+	// dev
+	// env
+}
+
+func Example_envOrProfile() {
+	os.Setenv("CFY_TEST_HOST", "env-host")
+	fmt.Println(envOrProfile("CFY_TEST_HOST", "profile-host", "localhost"))
+	os.Unsetenv("CFY_TEST_HOST")
+	fmt.Println(envOrProfile("CFY_TEST_HOST", "profile-host", "localhost"))
+	fmt.Println(envOrProfile("CFY_TEST_HOST", "", "localhost"))
+	// Output: env-host
+	// profile-host
+	// localhost
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const progressBarWidth = 40
+
+// progressBar - print progress of uploads and downloads in one line
+type progressBar struct {
+	output io.Writer
+	// last time when progress was printed
+	printed time.Time
+}
+
+// formatSize - human readable size
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KiB", "MiB", "GiB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f TiB", value)
+}
+
+// progressLine - progress bar for known size or transferred size only
+func progressLine(done, total int64) string {
+	if total <= 0 {
+		return fmt.Sprintf("Transferred %s", formatSize(done))
+	}
+	filled := int(done * progressBarWidth / total)
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	return fmt.Sprintf("[%s%s] %3d%% %s/%s",
+		strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled),
+		done*100/total, formatSize(done), formatSize(total))
+}
+
+// Update - print progress, not often than 10 times in second
+func (bar *progressBar) Update(done, total int64) {
+	finished := done == total
+	if !finished && time.Since(bar.printed) < 100*time.Millisecond {
+		return
+	}
+	bar.printed = time.Now()
+	fmt.Fprintf(bar.output, "\r%s", progressLine(done, total))
+	if finished {
+		fmt.Fprintln(bar.output)
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+)
+
+func Example_progressLine() {
+	fmt.Println(progressLine(1024, 4096))
+	fmt.Println(progressLine(4096, 4096))
+	fmt.Println(progressLine(3*1024*1024, -1))
+	// Output: [==========                              ]  25% 1.0 KiB/4.0 KiB
+	// [========================================] 100% 4.0 KiB/4.0 KiB
+	// Transferred 3.0 MiB
+}
@@ -30,27 +30,27 @@ scaling-groups - operations related to Scaling Groups
 	instances: check instances in group in autoscale [node-type is optional]
 
 		cfy-go scaling-groups instances -deployment <deployment_name> -scalegroup <scale_group_name> -node-type <nodeType>
+
+	scale: run scale workflow for scaling group or node and show added and
+	removed instances, min/max instances are checked before run [-delta is 1 by default]
+
+		cfy-go scaling-groups scale -deployment <deployment_name> -scalegroup <scale_group_name> -delta -1
+		cfy-go scaling-groups scale -deployment <deployment_name> -scalegroup <node_name> -dry-run
 */
 package main
 
 import (
 	"fmt"
 	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
-	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 	"log"
 	"os"
 	"strings"
 )
 
-func scaleGroupPrint(deploymentScalingGroups map[string]cloudify.ScalingGroup, err error) int {
-	if err != nil {
-		log.Printf("Cloudify error: %s\n", err.Error())
-		return 1
-	}
-
-	lines := make([][]string, len(deploymentScalingGroups))
-	var pos int
-	if deploymentScalingGroups != nil {
+func scaleGroupTable(deploymentScalingGroups map[string]cloudify.ScalingGroup) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(deploymentScalingGroups))
+		var pos int
 		for groupName, scaleGroup := range deploymentScalingGroups {
 			lines[pos] = make([]string, 7)
 			lines[pos][0] = groupName
@@ -62,16 +62,111 @@ func scaleGroupPrint(deploymentScalingGroups map[string]cloudify.ScalingGroup, e
 			lines[pos][6] = fmt.Sprintf("%d", scaleGroup.Properties.CurrentInstances)
 			pos++
 		}
+		return []string{
+			"Group name", "Members", "Min Instances", "Planned Instances",
+			"Default Instances", "Max Instances", "Current Instances",
+		}, lines
+	}
+}
+
+func scaleGroupPrint(deploymentScalingGroups map[string]cloudify.ScalingGroup, err error) int {
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+
+	return printOutput(deploymentScalingGroups, scaleGroupTable(deploymentScalingGroups))
+}
+
+func scalePlanTable(plan *cloudify.ScalePlan) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		entityType := "node"
+		if plan.IsGroup {
+			entityType = "scaling group"
+		}
+		return []string{
+			"Scalable entity", "Type", "Delta", "Min Instances",
+			"Max Instances", "Planned Instances", "New Planned Instances",
+		}, [][]string{{
+			plan.ScalableEntity, entityType, fmt.Sprintf("%d", plan.Delta),
+			fmt.Sprintf("%d", plan.Properties.MinInstances),
+			fmt.Sprintf("%d", plan.Properties.MaxInstances),
+			fmt.Sprintf("%d", plan.Properties.PlannedInstances),
+			fmt.Sprintf("%d", plan.PlannedInstances),
+		}}
+	}
+}
+
+func scaleResultPrint(scale *cloudify.ScaleResult, includeInstances bool) int {
+	// csv output contains only plan
+	if res := printOutput(scale, scalePlanTable(&scale.Plan)); res != 0 || !isTableOutput() {
+		return res
+	}
+	if res := executionPrint(&scale.Result.Execution, nil); res != 0 || !includeInstances {
+		return res
+	}
+	var changed = map[string][]cloudify.NodeInstance{
+		"added":   scale.Added,
+		"removed": scale.Removed,
+	}
+	return printOutput(changed, groupedTable("Change", []string{"added", "removed"},
+		func(groupName string) tableBuilder {
+			return nodeInstancesTable(changed[groupName])
+		}))
+}
+
+func scaleGroupCall(options []string) int {
+	operFlagSet := basicOptions("scaling-groups scale")
+	var deployment string
+	var scalegroup string
+	var delta int
+	var includeInstances bool
+	var dryRun bool
+	operFlagSet.StringVar(&deployment, "deployment", "",
+		"The unique identifier for the deployment")
+	operFlagSet.StringVar(&scalegroup, "scalegroup", "",
+		"The unique identifier for the scalegroup or node")
+	operFlagSet.IntVar(&delta, "delta", 1,
+		"Count of instances to add, negative for remove")
+	operFlagSet.BoolVar(&includeInstances, "instances", true,
+		"Show instances added or removed by scale")
+	operFlagSet.BoolVar(&dryRun, "dry-run", false,
+		"Only check that scale is possible")
+
+	operFlagSet.Parse(options)
+
+	if deployment == "" {
+		fmt.Println("Please provide deployment")
+		return 1
+	}
+	if scalegroup == "" {
+		fmt.Println("Please provide scalegroup")
+		return 1
+	}
+
+	cl := getClient()
+	if dryRun {
+		plan, err := cl.PlanScale(deployment, scalegroup, delta)
+		if err != nil {
+			log.Printf("Cloudify error: %s\n", err.Error())
+			return 1
+		}
+		return printOutput(plan, scalePlanTable(plan))
+	}
+
+	if isTableOutput() {
+		cl.SetLifecycleHandler(printLifecycleStep)
 	}
-	utils.PrintTable([]string{
-		"Group name", "Members", "Min Instances", "Planned Instances",
-		"Default Instances", "Max Instances", "Current Instances",
-	}, lines)
-	return 0
+	scale, err := cl.ScaleGroup(deployment, scalegroup, delta, includeInstances)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return scaleResultPrint(scale, includeInstances)
 }
 
 func scalingGroupsOptions(args, options []string) int {
-	defaultError := "info/nodes/instances/groups subcommand with deployment and scalegroup params is required"
+	defaultError := "info/nodes/instances/groups/scale subcommand with deployment and scalegroup params is required"
 
 	if len(args) < 3 {
 		fmt.Println(defaultError)
@@ -79,6 +174,10 @@ func scalingGroupsOptions(args, options []string) int {
 	}
 
 	switch args[2] {
+	case "scale":
+		{
+			return scaleGroupCall(options)
+		}
 	case "groups":
 		{
 			operFlagSet := basicOptions("scaling-groups groups")
@@ -109,13 +208,7 @@ func scalingGroupsOptions(args, options []string) int {
 				log.Printf("Cloudify error: %s\n", err.Error())
 				return 1
 			}
-			for groupName, instances := range groupedInstances {
-				fmt.Printf("Scale group: %v\n", groupName)
-				if nodeInstancesPrint(&instances, nil) != 0 {
-					return 1
-				}
-			}
-			return 0
+			return groupedInstancesPrint("Scale group", groupedInstances)
 		}
 	case "instances":
 		{
@@ -224,5 +317,4 @@ func scalingGroupsOptions(args, options []string) int {
 			return 1
 		}
 	}
-	return 0
 }
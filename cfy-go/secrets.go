@@ -0,0 +1,284 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Secrets
+
+secrets - Handle secrets on the manager
+
+	Values are read from file or from stdin (use `-` as file name),
+	so values are never saved in shell history.
+
+	create: Create a secret [manager only].
+
+		cfy-go secrets create <key> -value-file <path>
+		cat <path> | cfy-go secrets create <key> -value-file - -visibility tenant -hidden
+
+	delete: Delete a secret [manager only].
+
+		cfy-go secrets delete <key>
+
+	get: Retrieve secret with value [manager only].
+
+		cfy-go secrets get <key>
+
+	list: List secrets without values [manager only].
+
+		cfy-go secrets list
+
+	Paggination by:
+		`-offset`:  the number of resources to skip.
+		`-size`: the max size of the result subset to receive.
+		`-all`: request all pages starting from offset.
+
+	update: Update value, visibility or hidden flag of existed secret,
+	options which are not set are kept unchanged [manager only].
+
+		cfy-go secrets update <key> [-value-file <path>] [-visibility <visibility>] [-hidden=<true|false>]
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+)
+
+func secretsTable(secrets []cloudify.Secret, withValue bool) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(secrets))
+		for pos, secret := range secrets {
+			lines[pos] = make([]string, 7)
+			lines[pos][0] = secret.Key
+			lines[pos][1] = secret.CreatedAt
+			lines[pos][2] = secret.UpdatedAt
+			lines[pos][3] = secret.Visibility
+			lines[pos][4] = fmt.Sprintf("%v", secret.IsHiddenValue)
+			lines[pos][5] = secret.Tenant
+			lines[pos][6] = secret.CreatedBy
+			if withValue {
+				lines[pos] = append(lines[pos], secret.Value)
+			}
+		}
+		titles := []string{
+			"key", "created_at", "updated_at", "visibility",
+			"is_hidden_value", "tenant_name", "created_by",
+		}
+		if withValue {
+			titles = append(titles, "value")
+		}
+		return titles, lines
+	}
+}
+
+func printSecrets(secrets []cloudify.Secret, withValue bool) int {
+	return printOutput(secrets, secretsTable(secrets, withValue))
+}
+
+// readSecretValue - read value from file or from stdin if path is "-",
+// trailing new line is removed
+func readSecretValue(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(value, "\r"), nil
+}
+
+// parseSecretFlags - parse flags for create secret call
+func parseSecretFlags(operFlagSet *flag.FlagSet, args, options []string) (string, *cloudify.SecretPost, int) {
+	if len(args) < 4 {
+		fmt.Println("Secret key required")
+		return "", nil, 1
+	}
+
+	var valueFile string
+	var secret cloudify.SecretPost
+	operFlagSet.StringVar(&valueFile, "value-file", "",
+		"Read secret value from file, use '-' for read from stdin")
+	operFlagSet.StringVar(&secret.Visibility, "visibility", "",
+		"Secret visibility: private, tenant or global")
+	operFlagSet.BoolVar(&secret.IsHiddenValue, "hidden", false,
+		"Hide secret value from users without owner permissions")
+	operFlagSet.Parse(options)
+
+	if valueFile == "" {
+		fmt.Println("Value file required, use '-' for read value from stdin")
+		return "", nil, 1
+	}
+
+	value, err := readSecretValue(valueFile)
+	if err != nil {
+		log.Printf("Can't read secret value: %s\n", err.Error())
+		return "", nil, 1
+	}
+	secret.Value = value
+	return args[3], &secret, 0
+}
+
+func createSecretCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	var updateIfExists bool
+	operFlagSet.BoolVar(&updateIfExists, "update-if-exists", false,
+		"Update value if secret already exists")
+
+	key, secret, code := parseSecretFlags(operFlagSet, args, options)
+	if code != 0 {
+		return code
+	}
+	secret.UpdateIfExists = updateIfExists
+
+	cl := getClient()
+	created, err := cl.CreateSecret(key, *secret)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printOutput(*created, secretsTable([]cloudify.Secret{*created}, false))
+}
+
+func updateSecretCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Secret key required")
+		return 1
+	}
+
+	var valueFile string
+	var hidden bool
+	var secret cloudify.SecretPatch
+	operFlagSet.StringVar(&valueFile, "value-file", "",
+		"Read new secret value from file, use '-' for read from stdin")
+	operFlagSet.StringVar(&secret.Visibility, "visibility", "",
+		"New secret visibility: private, tenant or global")
+	operFlagSet.BoolVar(&hidden, "hidden", false,
+		"Hide secret value from users without owner permissions, use -hidden=false for show value")
+	operFlagSet.Parse(options)
+
+	// change hidden flag only if it is set in command line
+	operFlagSet.Visit(func(option *flag.Flag) {
+		if option.Name == "hidden" {
+			secret.IsHiddenValue = &hidden
+		}
+	})
+
+	if valueFile != "" {
+		value, err := readSecretValue(valueFile)
+		if err != nil {
+			log.Printf("Can't read secret value: %s\n", err.Error())
+			return 1
+		}
+		secret.Value = &value
+	}
+
+	if secret.Value == nil && secret.Visibility == "" && secret.IsHiddenValue == nil {
+		fmt.Println("Nothing to update, set value file, visibility or hidden flag")
+		return 1
+	}
+
+	cl := getClient()
+	updated, err := cl.UpdateSecret(args[3], secret)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printOutput(*updated, secretsTable([]cloudify.Secret{*updated}, false))
+}
+
+func getSecretCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Secret key required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	secret, err := cl.GetSecret(args[3])
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printOutput(*secret, secretsTable([]cloudify.Secret{*secret}, true))
+}
+
+func deleteSecretCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Secret key required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	secret, err := cl.DeleteSecret(args[3])
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printOutput(*secret, secretsTable([]cloudify.Secret{*secret}, false))
+}
+
+func listSecretsCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	params := parsePagination(operFlagSet, options)
+
+	cl := getClient()
+	getSecrets := cl.GetSecrets
+	if listAllRequested(operFlagSet) {
+		getSecrets = cl.GetAllSecrets
+	}
+	secrets, err := getSecrets(params)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if res := printSecrets(secrets.Items, false); res != 0 || !isTableOutput() {
+		return res
+	}
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
+		secrets.Metadata.Pagination.Offset, len(secrets.Items),
+		secrets.Metadata.Pagination.Total)
+	return 0
+}
+
+func secretsOptions(args, options []string) int {
+	var secretsCalls = []CommandInfo{{
+		CommandName: "list",
+		Callback:    listSecretsCall,
+	}, {
+		CommandName: "get",
+		Callback:    getSecretCall,
+	}, {
+		CommandName: "create",
+		Callback:    createSecretCall,
+	}, {
+		CommandName: "update",
+		Callback:    updateSecretCall,
+	}, {
+		CommandName: "delete",
+		Callback:    deleteSecretCall,
+	}}
+
+	return ParseCalls(secretsCalls, 3, args, options)
+}
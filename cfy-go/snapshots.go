@@ -0,0 +1,291 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Snapshots
+
+snapshots - Handle manager snapshots
+
+	create: Create a snapshot on the manager [manager only].
+
+		cfy-go snapshots create <snapshot id>
+		cfy-go snapshots create <snapshot id> -include-logs -include-events -wait
+
+	delete: Delete a snapshot from the manager [manager only].
+
+		cfy-go snapshots delete <snapshot id>
+
+	download: Download a snapshot from the manager [manager only].
+
+		cfy-go snapshots download <snapshot id> -output-path backup.zip
+
+	list: List snapshots on the manager [manager only].
+
+		cfy-go snapshots list
+
+	Paggination by:
+		`-offset`:  the number of resources to skip.
+		`-size`: the max size of the result subset to receive.
+		`-all`: request all pages starting from offset.
+
+	restore: Restore the manager from a snapshot [manager only].
+
+		cfy-go snapshots restore <snapshot id> -force -wait
+
+	upload: Upload a snapshot to the manager [manager only].
+
+		cfy-go snapshots upload <snapshot id> -snapshot-path backup.zip
+
+	Use `-wait` for wait until execution finished, exit code is 1 if
+	execution is not terminated successfully.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
+	"log"
+)
+
+func snapshotsTable(snapshots []cloudify.Snapshot) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(snapshots))
+		for pos, snapshot := range snapshots {
+			lines[pos] = make([]string, 6)
+			lines[pos][0] = snapshot.ID
+			lines[pos][1] = snapshot.CreatedAt
+			lines[pos][2] = snapshot.Status
+			lines[pos][3] = snapshot.ErrorMessage
+			lines[pos][4] = snapshot.Tenant
+			lines[pos][5] = snapshot.CreatedBy
+		}
+		return []string{
+			"id", "created_at", "status", "error", "tenant_name", "created_by",
+		}, lines
+	}
+}
+
+func printSnapshot(snapshot cloudify.Snapshot) int {
+	return printOutput(snapshot, snapshotsTable([]cloudify.Snapshot{snapshot}))
+}
+
+// snapshotExecutionPrint - print snapshot execution, with wait print result
+// of wait and return error if execution is not terminated
+func snapshotExecutionPrint(cl *cloudify.Client, execution *cloudify.Execution, wait bool) int {
+	if !wait {
+		return executionPrint(execution, nil)
+	}
+	if isTableOutput() {
+		executionPrint(execution, nil)
+	}
+
+	result, err := cl.WaitExecution(execution.ID)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if res := executionPrint(&result.Execution, nil); res != 0 {
+		return res
+	}
+	if result.State != cloudify.WaitTerminated {
+		log.Printf("Execution %s %s\n", result.Execution.ID, result.State)
+		return 1
+	}
+	return 0
+}
+
+func listSnapshotsCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	var snapshotID string
+	operFlagSet.StringVar(&snapshotID, "snapshot-id", "",
+		"The unique identifier for the snapshot")
+
+	params := parsePagination(operFlagSet, options)
+
+	if snapshotID != "" {
+		params["id"] = snapshotID
+	}
+
+	cl := getClient()
+	getSnapshots := cl.GetSnapshots
+	if listAllRequested(operFlagSet) {
+		getSnapshots = cl.GetAllSnapshots
+	}
+	snapshots, err := getSnapshots(params)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	res := printOutput(snapshots.Items, snapshotsTable(snapshots.Items))
+	if res != 0 || !isTableOutput() {
+		return res
+	}
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
+		snapshots.Metadata.Pagination.Offset, len(snapshots.Items),
+		snapshots.Metadata.Pagination.Total)
+	return 0
+}
+
+func createSnapshotCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Snapshot Id required")
+		return 1
+	}
+
+	var snapshot cloudify.SnapshotPost
+	var wait bool
+	operFlagSet.BoolVar(&snapshot.IncludeMetrics, "include-metrics", false,
+		"Include metrics data in the snapshot")
+	operFlagSet.BoolVar(&snapshot.IncludeCredentials, "include-credentials", false,
+		"Include agent SSH keys in the snapshot")
+	operFlagSet.BoolVar(&snapshot.IncludeLogs, "include-logs", false,
+		"Include logs in the snapshot")
+	operFlagSet.BoolVar(&snapshot.IncludeEvents, "include-events", false,
+		"Include events in the snapshot")
+	operFlagSet.BoolVar(&snapshot.Queue, "queue", false,
+		"Create snapshot after currently running executions")
+	operFlagSet.BoolVar(&wait, "wait", false,
+		"Wait until snapshot created")
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	execution, err := cl.CreateSnapshot(args[3], snapshot)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return snapshotExecutionPrint(cl, execution, wait)
+}
+
+func deleteSnapshotCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Snapshot Id required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	snapshot, err := cl.DeleteSnapshot(args[3])
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printSnapshot(snapshot.Snapshot)
+}
+
+func downloadSnapshotCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Snapshot Id required")
+		return 1
+	}
+
+	var outputPath string
+	operFlagSet.StringVar(&outputPath, "output-path", "",
+		"The snapshot archive path, <snapshot id>.zip by default")
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	snapshotPath, err := cl.DownloadSnapshot(args[3], outputPath)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	fmt.Printf("Snapshot saved to %s\n", snapshotPath)
+	return 0
+}
+
+func uploadSnapshotCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Snapshot Id required")
+		return 1
+	}
+
+	var snapshotPath string
+	operFlagSet.StringVar(&snapshotPath, "snapshot-path", "",
+		"The snapshot archive path")
+	operFlagSet.Parse(options)
+
+	if snapshotPath == "" {
+		fmt.Println("Snapshot path required")
+		return 1
+	}
+
+	cl := getClient()
+	snapshot, err := cl.UploadSnapshot(args[3], snapshotPath)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printSnapshot(snapshot.Snapshot)
+}
+
+func restoreSnapshotCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Snapshot Id required")
+		return 1
+	}
+
+	var restore cloudify.SnapshotRestorePost
+	var wait bool
+	operFlagSet.BoolVar(&restore.Force, "force", false,
+		"Restore even if manager is not empty")
+	operFlagSet.StringVar(&restore.Tenant, "restore-tenant", "",
+		"Tenant for restore snapshot created on old manager version")
+	operFlagSet.BoolVar(&restore.RestoreCertificates, "restore-certificates", false,
+		"Restore manager certificates from snapshot")
+	operFlagSet.BoolVar(&restore.NoReboot, "no-reboot", false,
+		"Don't reboot manager after restore certificates")
+	operFlagSet.BoolVar(&restore.RecreateDeploymentsEnvs, "recreate-deployments-envs", false,
+		"Recreate deployments virtual environments")
+	operFlagSet.BoolVar(&restore.IgnorePluginFailure, "ignore-plugin-failure", false,
+		"Ignore plugins installation failures")
+	operFlagSet.BoolVar(&wait, "wait", false,
+		"Wait until snapshot restored")
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	execution, err := cl.RestoreSnapshot(args[3], restore)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return snapshotExecutionPrint(cl, execution, wait)
+}
+
+func snapshotsOptions(args, options []string) int {
+	var snapshotsCalls = []CommandInfo{{
+		CommandName: "list",
+		Callback:    listSnapshotsCall,
+	}, {
+		CommandName: "create",
+		Callback:    createSnapshotCall,
+	}, {
+		CommandName: "delete",
+		Callback:    deleteSnapshotCall,
+	}, {
+		CommandName: "download",
+		Callback:    downloadSnapshotCall,
+	}, {
+		CommandName: "upload",
+		Callback:    uploadSnapshotCall,
+	}, {
+		CommandName: "restore",
+		Callback:    restoreSnapshotCall,
+	}}
+
+	return ParseCalls(snapshotsCalls, 3, args, options)
+}
@@ -17,55 +17,220 @@ limitations under the License.
 /*
 Tenants
 
-tenants - List tenants in this instance of cloudify manager [manager only].
+tenants - Handle tenants in this instance of cloudify manager [manager only].
 
-		- cfy-go tenants list
+	list: List tenants.
+
+		cfy-go tenants list
+
+	get: Retrieve tenant information.
+
+		cfy-go tenants get <tenant name>
+
+	create: Create a tenant.
+
+		cfy-go tenants create <tenant name>
+
+	delete: Delete a tenant.
+
+		cfy-go tenants delete <tenant name>
+
+	add-user: Add user to tenant with role (viewer, user, operations, manager).
+
+		cfy-go tenants add-user <tenant name> -user <username> -role user
+
+	remove-user: Remove user from tenant.
+
+		cfy-go tenants remove-user <tenant name> -user <username>
+
+	add-group: Add user group to tenant with role.
+
+		cfy-go tenants add-group <tenant name> -group <group name> -role viewer
+
+	remove-group: Remove user group from tenant.
+
+		cfy-go tenants remove-group <tenant name> -group <group name>
 */
 
 package main
 
 import (
+	"flag"
 	"fmt"
-	"github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
 	"log"
 	"strconv"
 )
 
-func tenantsOptions(args, options []string) int {
-	defaultError := "list subcommand is required"
+func tenantsTable(tenants []cloudify.Tenant) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(tenants))
+		for pos, tenant := range tenants {
+			lines[pos] = make([]string, 3)
+			lines[pos][0] = tenant.Name
+			lines[pos][1] = strconv.Itoa(tenant.Users)
+			lines[pos][2] = strconv.Itoa(tenant.Groups)
+		}
+		return []string{"name", "users", "groups"}, lines
+	}
+}
 
-	if len(args) < 3 {
-		fmt.Println(defaultError)
+func printTenants(tenants []cloudify.Tenant) int {
+	return printOutput(tenants, tenantsTable(tenants))
+}
+
+func tenantPrint(tenant *cloudify.Tenant, err error) int {
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
 		return 1
 	}
-	switch args[2] {
-	case "list":
-		{
-			operFlagSet := basicOptions("tenants list")
-			params := parsePagination(operFlagSet, options)
-			cl := getClient()
-			tenants, err := cl.GetTenants(params)
-			if err != nil {
-				log.Printf("Cloudify error: %s\n", err.Error())
-				return 1
-			}
-			lines := make([][]string, len(tenants.Items))
-			for pos, tenant := range tenants.Items {
-				lines[pos] = make([]string, 3)
-				lines[pos][0] = tenant.Name
-				lines[pos][1] = strconv.Itoa(tenant.Users)
-				lines[pos][2] = strconv.Itoa(tenant.Groups)
-			}
-			utils.PrintTable([]string{"name", "users", "groups"}, lines)
-			fmt.Printf("Showed %d+%d/%d results. Use offset/size for get more.\n",
-				tenants.Metadata.Pagination.Offset, len(tenants.Items),
-				tenants.Metadata.Pagination.Total)
-		}
-	default:
-		{
-			fmt.Println(defaultError)
-			return 1
-		}
+	return printOutput(*tenant, tenantsTable([]cloudify.Tenant{*tenant}))
+}
+
+func listTenantsCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	params := parsePagination(operFlagSet, options)
+	cl := getClient()
+	getTenants := cl.GetTenants
+	if listAllRequested(operFlagSet) {
+		getTenants = cl.GetAllTenants
+	}
+	tenants, err := getTenants(params)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if res := printTenants(tenants.Items); res != 0 || !isTableOutput() {
+		return res
 	}
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
+		tenants.Metadata.Pagination.Offset, len(tenants.Items),
+		tenants.Metadata.Pagination.Total)
 	return 0
 }
+
+func getTenantCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Tenant name required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	return tenantPrint(cl.GetTenant(args[3]))
+}
+
+func createTenantCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Tenant name required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	return tenantPrint(cl.CreateTenant(args[3]))
+}
+
+func deleteTenantCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Tenant name required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	return tenantPrint(cl.DeleteTenant(args[3]))
+}
+
+// parseTenantMemberFlags - parse tenant name, member name and role if withRole is set
+func parseTenantMemberFlags(operFlagSet *flag.FlagSet, args, options []string, member string, withRole bool) (string, string, string, bool) {
+	if len(args) < 4 {
+		fmt.Println("Tenant name required")
+		return "", "", "", false
+	}
+
+	var name string
+	var role string
+	operFlagSet.StringVar(&name, member, "",
+		"The unique identifier for the "+member)
+	if withRole {
+		operFlagSet.StringVar(&role, "role", cloudify.TenantRoleUser,
+			"Role in tenant: viewer, user, operations or manager")
+	}
+	operFlagSet.Parse(options)
+
+	if name == "" {
+		fmt.Printf("Value for -%s required\n", member)
+		return "", "", "", false
+	}
+	return args[3], name, role, true
+}
+
+func addUserTenantCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	tenantName, username, role, ok := parseTenantMemberFlags(operFlagSet, args, options, "user", true)
+	if !ok {
+		return 1
+	}
+
+	cl := getClient()
+	return tenantPrint(cl.AddUserToTenant(tenantName, username, role))
+}
+
+func removeUserTenantCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	tenantName, username, _, ok := parseTenantMemberFlags(operFlagSet, args, options, "user", false)
+	if !ok {
+		return 1
+	}
+
+	cl := getClient()
+	return tenantPrint(cl.RemoveUserFromTenant(tenantName, username))
+}
+
+func addGroupTenantCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	tenantName, groupName, role, ok := parseTenantMemberFlags(operFlagSet, args, options, "group", true)
+	if !ok {
+		return 1
+	}
+
+	cl := getClient()
+	return tenantPrint(cl.AddUserGroupToTenant(tenantName, groupName, role))
+}
+
+func removeGroupTenantCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	tenantName, groupName, _, ok := parseTenantMemberFlags(operFlagSet, args, options, "group", false)
+	if !ok {
+		return 1
+	}
+
+	cl := getClient()
+	return tenantPrint(cl.RemoveUserGroupFromTenant(tenantName, groupName))
+}
+
+func tenantsOptions(args, options []string) int {
+	var tenantsCalls = []CommandInfo{{
+		CommandName: "list",
+		Callback:    listTenantsCall,
+	}, {
+		CommandName: "get",
+		Callback:    getTenantCall,
+	}, {
+		CommandName: "create",
+		Callback:    createTenantCall,
+	}, {
+		CommandName: "delete",
+		Callback:    deleteTenantCall,
+	}, {
+		CommandName: "add-user",
+		Callback:    addUserTenantCall,
+	}, {
+		CommandName: "remove-user",
+		Callback:    removeUserTenantCall,
+	}, {
+		CommandName: "add-group",
+		Callback:    addGroupTenantCall,
+	}, {
+		CommandName: "remove-group",
+		Callback:    removeGroupTenantCall,
+	}}
+
+	return ParseCalls(tenantsCalls, 3, args, options)
+}
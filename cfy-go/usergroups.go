@@ -0,0 +1,208 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+User groups
+
+user-groups - Handle user groups on the manager [manager only].
+
+	list: List user groups.
+
+		cfy-go user-groups list
+
+	get: Retrieve user group information.
+
+		cfy-go user-groups get <group name>
+
+	create: Create a user group, optionally linked to LDAP group.
+
+		cfy-go user-groups create <group name> -ldap-dn <ldap group dn> -role default
+
+	delete: Delete a user group.
+
+		cfy-go user-groups delete <group name>
+
+	add-user: Add user to group.
+
+		cfy-go user-groups add-user <group name> -user <username>
+
+	remove-user: Remove user from group.
+
+		cfy-go user-groups remove-user <group name> -user <username>
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
+	"log"
+	"strconv"
+)
+
+func userGroupsTable(groups []cloudify.UserGroup) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(groups))
+		for pos, group := range groups {
+			lines[pos] = make([]string, 5)
+			lines[pos][0] = group.Name
+			lines[pos][1] = group.LdapDN
+			lines[pos][2] = group.Role
+			lines[pos][3] = strconv.Itoa(group.Tenants)
+			lines[pos][4] = strconv.Itoa(group.Users)
+		}
+		return []string{
+			"name", "ldap_dn", "role", "tenants", "users",
+		}, lines
+	}
+}
+
+func printUserGroups(groups []cloudify.UserGroup) int {
+	return printOutput(groups, userGroupsTable(groups))
+}
+
+func userGroupPrint(group *cloudify.UserGroup, err error) int {
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printOutput(*group, userGroupsTable([]cloudify.UserGroup{*group}))
+}
+
+func listUserGroupsCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	params := parsePagination(operFlagSet, options)
+	cl := getClient()
+	getUserGroups := cl.GetUserGroups
+	if listAllRequested(operFlagSet) {
+		getUserGroups = cl.GetAllUserGroups
+	}
+	groups, err := getUserGroups(params)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if res := printUserGroups(groups.Items); res != 0 || !isTableOutput() {
+		return res
+	}
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
+		groups.Metadata.Pagination.Offset, len(groups.Items),
+		groups.Metadata.Pagination.Total)
+	return 0
+}
+
+func getUserGroupCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Group name required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	return userGroupPrint(cl.GetUserGroup(args[3]))
+}
+
+func createUserGroupCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Group name required")
+		return 1
+	}
+
+	var group cloudify.UserGroupPost
+	operFlagSet.StringVar(&group.LdapGroupDN, "ldap-dn", "",
+		"LDAP group distinguished name")
+	operFlagSet.StringVar(&group.Role, "role", cloudify.SystemRoleDefault,
+		"System role: default or sys_admin")
+	operFlagSet.Parse(options)
+	group.GroupName = args[3]
+
+	cl := getClient()
+	return userGroupPrint(cl.CreateUserGroup(group))
+}
+
+func deleteUserGroupCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Group name required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	return userGroupPrint(cl.DeleteUserGroup(args[3]))
+}
+
+// parseGroupUserFlags - parse group name and username
+func parseGroupUserFlags(operFlagSet *flag.FlagSet, args, options []string) (string, string, bool) {
+	if len(args) < 4 {
+		fmt.Println("Group name required")
+		return "", "", false
+	}
+
+	var username string
+	operFlagSet.StringVar(&username, "user", "",
+		"The unique identifier for the user")
+	operFlagSet.Parse(options)
+
+	if username == "" {
+		fmt.Println("Value for -user required")
+		return "", "", false
+	}
+	return args[3], username, true
+}
+
+func addUserUserGroupCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	groupName, username, ok := parseGroupUserFlags(operFlagSet, args, options)
+	if !ok {
+		return 1
+	}
+
+	cl := getClient()
+	return userGroupPrint(cl.AddUserToGroup(groupName, username))
+}
+
+func removeUserUserGroupCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	groupName, username, ok := parseGroupUserFlags(operFlagSet, args, options)
+	if !ok {
+		return 1
+	}
+
+	cl := getClient()
+	return userGroupPrint(cl.RemoveUserFromGroup(groupName, username))
+}
+
+func userGroupsOptions(args, options []string) int {
+	var userGroupsCalls = []CommandInfo{{
+		CommandName: "list",
+		Callback:    listUserGroupsCall,
+	}, {
+		CommandName: "get",
+		Callback:    getUserGroupCall,
+	}, {
+		CommandName: "create",
+		Callback:    createUserGroupCall,
+	}, {
+		CommandName: "delete",
+		Callback:    deleteUserGroupCall,
+	}, {
+		CommandName: "add-user",
+		Callback:    addUserUserGroupCall,
+	}, {
+		CommandName: "remove-user",
+		Callback:    removeUserUserGroupCall,
+	}}
+
+	return ParseCalls(userGroupsCalls, 3, args, options)
+}
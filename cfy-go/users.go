@@ -0,0 +1,267 @@
+/*
+Copyright (c) 2018 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Users
+
+users - Handle users on the manager [manager only].
+
+	list: List users.
+
+		cfy-go users list
+
+	get: Retrieve user information.
+
+		cfy-go users get <username>
+
+	create: Create a user, password is read from file or from stdin (use `-`).
+
+		cfy-go users create <username> -password-file - -role default
+
+	delete: Delete a user.
+
+		cfy-go users delete <username>
+
+	set-password: Change user password, password is read from file or from stdin.
+
+		cfy-go users set-password <username> -password-file <path>
+
+	set-role: Change user system role (default, sys_admin).
+
+		cfy-go users set-role <username> -role sys_admin
+
+	activate: Activate a user.
+
+		cfy-go users activate <username>
+
+	deactivate: Deactivate a user.
+
+		cfy-go users deactivate <username>
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
+	"log"
+	"strconv"
+)
+
+func usersTable(users []cloudify.User) tableBuilder {
+	return func(wide bool) ([]string, [][]string) {
+		lines := make([][]string, len(users))
+		for pos, user := range users {
+			lines[pos] = make([]string, 6)
+			lines[pos][0] = user.Username
+			lines[pos][1] = user.Role
+			lines[pos][2] = fmt.Sprintf("%v", user.Active)
+			lines[pos][3] = user.LastLoginAt
+			lines[pos][4] = strconv.Itoa(user.Tenants)
+			lines[pos][5] = strconv.Itoa(user.Groups)
+		}
+		return []string{
+			"username", "role", "active", "last_login_at", "tenants", "groups",
+		}, lines
+	}
+}
+
+func printUsers(users []cloudify.User) int {
+	return printOutput(users, usersTable(users))
+}
+
+func userPrint(user *cloudify.User, err error) int {
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	return printOutput(*user, usersTable([]cloudify.User{*user}))
+}
+
+// readPasswordFlag - read password from file set by -password-file
+func readPasswordFlag(passwordFile string) (string, bool) {
+	if passwordFile == "" {
+		fmt.Println("Password file required, use '-' for read password from stdin")
+		return "", false
+	}
+	password, err := readSecretValue(passwordFile)
+	if err != nil {
+		log.Printf("Can't read password: %s\n", err.Error())
+		return "", false
+	}
+	return password, true
+}
+
+func listUsersCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	params := parsePagination(operFlagSet, options)
+	cl := getClient()
+	getUsers := cl.GetUsers
+	if listAllRequested(operFlagSet) {
+		getUsers = cl.GetAllUsers
+	}
+	users, err := getUsers(params)
+	if err != nil {
+		log.Printf("Cloudify error: %s\n", err.Error())
+		return 1
+	}
+	if res := printUsers(users.Items); res != 0 || !isTableOutput() {
+		return res
+	}
+	fmt.Printf("Showed %d+%d/%d results. Use offset/size or all for get more.\n",
+		users.Metadata.Pagination.Offset, len(users.Items),
+		users.Metadata.Pagination.Total)
+	return 0
+}
+
+func getUserCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Username required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	return userPrint(cl.GetUser(args[3]))
+}
+
+func createUserCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Username required")
+		return 1
+	}
+
+	var passwordFile string
+	var user cloudify.UserPost
+	operFlagSet.StringVar(&passwordFile, "password-file", "",
+		"Read password from file, use '-' for read from stdin")
+	operFlagSet.StringVar(&user.Role, "role", cloudify.SystemRoleDefault,
+		"System role: default or sys_admin")
+	operFlagSet.Parse(options)
+
+	password, ok := readPasswordFlag(passwordFile)
+	if !ok {
+		return 1
+	}
+	user.Username = args[3]
+	user.Password = password
+
+	cl := getClient()
+	return userPrint(cl.CreateUser(user))
+}
+
+func deleteUserCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Username required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	return userPrint(cl.DeleteUser(args[3]))
+}
+
+func setPasswordUserCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Username required")
+		return 1
+	}
+
+	var passwordFile string
+	operFlagSet.StringVar(&passwordFile, "password-file", "",
+		"Read password from file, use '-' for read from stdin")
+	operFlagSet.Parse(options)
+
+	password, ok := readPasswordFlag(passwordFile)
+	if !ok {
+		return 1
+	}
+
+	cl := getClient()
+	return userPrint(cl.SetUserPassword(args[3], password))
+}
+
+func setRoleUserCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Username required")
+		return 1
+	}
+
+	var role string
+	operFlagSet.StringVar(&role, "role", "",
+		"System role: default or sys_admin")
+	operFlagSet.Parse(options)
+
+	if role == "" {
+		fmt.Println("Role required")
+		return 1
+	}
+
+	cl := getClient()
+	return userPrint(cl.SetUserRole(args[3], role))
+}
+
+func activateUserCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Username required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	return userPrint(cl.ActivateUser(args[3]))
+}
+
+func deactivateUserCall(operFlagSet *flag.FlagSet, args, options []string) int {
+	if len(args) < 4 {
+		fmt.Println("Username required")
+		return 1
+	}
+	operFlagSet.Parse(options)
+
+	cl := getClient()
+	return userPrint(cl.DeactivateUser(args[3]))
+}
+
+func usersOptions(args, options []string) int {
+	var usersCalls = []CommandInfo{{
+		CommandName: "list",
+		Callback:    listUsersCall,
+	}, {
+		CommandName: "get",
+		Callback:    getUserCall,
+	}, {
+		CommandName: "create",
+		Callback:    createUserCall,
+	}, {
+		CommandName: "delete",
+		Callback:    deleteUserCall,
+	}, {
+		CommandName: "set-password",
+		Callback:    setPasswordUserCall,
+	}, {
+		CommandName: "set-role",
+		Callback:    setRoleUserCall,
+	}, {
+		CommandName: "activate",
+		Callback:    activateUserCall,
+	}, {
+		CommandName: "deactivate",
+		Callback:    deactivateUserCall,
+	}}
+
+	return ParseCalls(usersCalls, 3, args, options)
+}
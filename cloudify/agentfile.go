@@ -40,6 +40,8 @@ func (cl *Client) updateHostFromAgent() {
 				cl.debugLogf("Can't parse config: %s\n", err.Error())
 			} else {
 				if agentConfig.RestPort != "" {
+					// agent always use https, certificate can be trusted by
+					// CACertFile/CACert or CertFingerprint settings
 					cl.Host = "https://" + agentConfig.RestHost + ":" + agentConfig.RestPort
 				} else {
 					cl.Host = agentConfig.RestHost
@@ -61,6 +63,11 @@ func ValidateBaseConnection(cloudConfig ClientConfig) error {
 		return fmt.Errorf("You have empty host")
 	}
 
+	// token is enough for authentication
+	if len(cloudConfig.Token) > 0 {
+		return nil
+	}
+
 	if len(cloudConfig.User) == 0 {
 		return fmt.Errorf("You have empty user")
 	}
@@ -20,8 +20,11 @@ Package cloudify - Rest API implementation.
 package cloudify
 
 import (
+	"context"
 	"fmt"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
+	"net/url"
 	"os"
 	"path/filepath"
 )
@@ -30,8 +33,8 @@ import (
 type Blueprint struct {
 	// have id, owner information
 	rest.Resource
-	MainFileName string `json:"main_file_name"`
-	// TODO describe "plan" struct
+	MainFileName string        `json:"main_file_name"`
+	Plan         BlueprintPlan `json:"plan"`
 }
 
 //BlueprintGet - Struct returned to get call with blueprint id
@@ -50,23 +53,90 @@ type Blueprints struct {
 
 //GetBlueprints - return blueprints from manager with fileter by params
 func (cl *Client) GetBlueprints(params map[string]string) (*Blueprints, error) {
+	return cl.GetBlueprintsWithContext(context.Background(), params)
+}
+
+//GetBlueprintsWithContext - return blueprints from manager with fileter by params,
+// canceled with context
+func (cl *Client) GetBlueprintsWithContext(ctx context.Context, params map[string]string) (*Blueprints, error) {
 	var blueprints Blueprints
 
 	values := cl.stringMapToURLValue(params)
 
-	err := cl.Get("blueprints?"+values.Encode(), &blueprints)
+	err := cl.GetWithContext(ctx, "blueprints?"+values.Encode(), &blueprints)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blueprints, nil
+}
+
+//IterateBlueprints - call handler for each blueprint filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateBlueprints(ctx context.Context, params map[string]string, handler func(Blueprint) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		blueprints, err := cl.GetBlueprintsWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range blueprints.Items {
+			if !handler(item) {
+				return blueprints.Metadata.Pagination, len(blueprints.Items), false, nil
+			}
+		}
+		return blueprints.Metadata.Pagination, len(blueprints.Items), true, nil
+	})
+}
+
+//GetAllBlueprints - return blueprints from all pages filtered by params
+func (cl *Client) GetAllBlueprints(params map[string]string) (*Blueprints, error) {
+	return cl.GetAllBlueprintsWithContext(context.Background(), params)
+}
+
+//GetAllBlueprintsWithContext - return blueprints from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllBlueprintsWithContext(ctx context.Context, params map[string]string) (*Blueprints, error) {
+	var blueprints Blueprints
+
+	err := cl.IterateBlueprints(ctx, params, func(item Blueprint) bool {
+		blueprints.Items = append(blueprints.Items, item)
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	blueprints.Metadata = allItemsMetadata(len(blueprints.Items))
 	return &blueprints, nil
 }
 
+//GetBlueprint - return blueprint with plan by id
+func (cl *Client) GetBlueprint(blueprintID string) (*Blueprint, error) {
+	return cl.GetBlueprintWithContext(context.Background(), blueprintID)
+}
+
+//GetBlueprintWithContext - return blueprint with plan by id, canceled with context
+func (cl *Client) GetBlueprintWithContext(ctx context.Context, blueprintID string) (*Blueprint, error) {
+	var blueprint BlueprintGet
+
+	err := cl.GetWithContext(ctx, "blueprints/"+blueprintID, &blueprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blueprint.Blueprint, nil
+}
+
 //DeleteBlueprints - delete blueprint by id
 func (cl *Client) DeleteBlueprints(blueprintID string) (*BlueprintGet, error) {
+	return cl.DeleteBlueprintsWithContext(context.Background(), blueprintID)
+}
+
+//DeleteBlueprintsWithContext - delete blueprint by id, canceled with context
+func (cl *Client) DeleteBlueprintsWithContext(ctx context.Context, blueprintID string) (*BlueprintGet, error) {
 	var blueprint BlueprintGet
 
-	err := cl.Delete("blueprints/"+blueprintID, nil, &blueprint)
+	err := cl.DeleteWithContext(ctx, "blueprints/"+blueprintID, nil, &blueprint)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +146,11 @@ func (cl *Client) DeleteBlueprints(blueprintID string) (*BlueprintGet, error) {
 
 //DownloadBlueprints - download blueprint by id
 func (cl *Client) DownloadBlueprints(blueprintID string) (string, error) {
+	return cl.DownloadBlueprintsWithContext(context.Background(), blueprintID)
+}
+
+//DownloadBlueprintsWithContext - download blueprint by id, canceled with context
+func (cl *Client) DownloadBlueprintsWithContext(ctx context.Context, blueprintID string) (string, error) {
 	fileName := blueprintID + ".tar.gz"
 
 	_, errFile := os.Stat(fileName)
@@ -83,7 +158,7 @@ func (cl *Client) DownloadBlueprints(blueprintID string) (string, error) {
 		return "", fmt.Errorf("file `%s` is exist", fileName)
 	}
 
-	err := cl.GetBinary("blueprints/"+blueprintID+"/archive", fileName)
+	err := cl.GetBinaryWithContext(ctx, "blueprints/"+blueprintID+"/archive", fileName)
 	if err != nil {
 		return "", err
 	}
@@ -91,8 +166,49 @@ func (cl *Client) DownloadBlueprints(blueprintID string) (string, error) {
 	return fileName, nil
 }
 
+//BlueprintUpload - options for upload blueprint
+type BlueprintUpload struct {
+	// main blueprint file name in archive, manager uses blueprint.yaml if empty
+	ApplicationFileName string
+	// tenant, private or global, manager default is used if empty
+	Visibility string
+	// format used for pack directory: zip, tar or tar.gz, zip if empty
+	ArchiveFormat string
+	// glob patterns of files and directories skipped on pack, e.g. .git or *.pyc
+	Exclude []string
+}
+
+//uploadURL - url for upload blueprint with options
+func (options *BlueprintUpload) uploadURL(blueprintID string, values url.Values) string {
+	if options.ApplicationFileName != "" {
+		values.Set("application_file_name", options.ApplicationFileName)
+	}
+	if options.Visibility != "" {
+		values.Set("visibility", options.Visibility)
+	}
+	return "blueprints/" + blueprintID + "?" + values.Encode()
+}
+
 //UploadBlueprint - upload blueprint with name and path to blueprint in filesystem
 func (cl *Client) UploadBlueprint(blueprintID, path string) (*BlueprintGet, error) {
+	return cl.UploadBlueprintWithContext(context.Background(), blueprintID, path)
+}
+
+//UploadBlueprintWithContext - upload blueprint with name and path to blueprint in filesystem,
+// canceled with context
+func (cl *Client) UploadBlueprintWithContext(ctx context.Context, blueprintID, path string) (*BlueprintGet, error) {
+	return cl.UploadBlueprintDirWithContext(ctx, blueprintID, path, BlueprintUpload{})
+}
+
+//UploadBlueprintDir - pack directory with blueprint and upload it, path is
+// path to main blueprint file in directory
+func (cl *Client) UploadBlueprintDir(blueprintID, path string, options BlueprintUpload) (*BlueprintGet, error) {
+	return cl.UploadBlueprintDirWithContext(context.Background(), blueprintID, path, options)
+}
+
+//UploadBlueprintDirWithContext - pack directory with blueprint and upload it,
+// path is path to main blueprint file in directory, canceled with context
+func (cl *Client) UploadBlueprintDirWithContext(ctx context.Context, blueprintID, path string, options BlueprintUpload) (*BlueprintGet, error) {
 
 	absPath, errAbs := filepath.Abs(path)
 	if errAbs != nil {
@@ -100,10 +216,74 @@ func (cl *Client) UploadBlueprint(blueprintID, path string) (*BlueprintGet, erro
 	}
 
 	dirPath, nameFile := filepath.Split(absPath)
+	if options.ApplicationFileName == "" {
+		options.ApplicationFileName = nameFile
+	}
+
+	stream, err := archiveStream([]string{dirPath}, options.ArchiveFormat, options.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	var blueprint BlueprintGet
+
+	err = cl.PutBinaryStreamWithContext(ctx, options.uploadURL(blueprintID, url.Values{}), stream, -1, &blueprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blueprint, nil
+}
+
+//UploadBlueprintArchive - upload existing zip, tar, tar.gz or tar.bz2 archive
+// with blueprint as is
+func (cl *Client) UploadBlueprintArchive(blueprintID, archivePath string, options BlueprintUpload) (*BlueprintGet, error) {
+	return cl.UploadBlueprintArchiveWithContext(context.Background(), blueprintID, archivePath, options)
+}
+
+//UploadBlueprintArchiveWithContext - upload existing zip, tar, tar.gz or
+// tar.bz2 archive with blueprint as is, canceled with context
+func (cl *Client) UploadBlueprintArchiveWithContext(ctx context.Context, blueprintID, archivePath string, options BlueprintUpload) (*BlueprintGet, error) {
+	if utils.ArchiveFormat(archivePath) == "" {
+		return nil, fmt.Errorf("file `%s` is not zip, tar, tar.gz or tar.bz2 archive", archivePath)
+	}
+
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	info, err := archive.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var blueprint BlueprintGet
+
+	err = cl.PutBinaryStreamWithContext(ctx, options.uploadURL(blueprintID, url.Values{}), archive, info.Size(), &blueprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blueprint, nil
+}
+
+//UploadBlueprintURL - upload blueprint from archive url, archive is downloaded by manager
+func (cl *Client) UploadBlueprintURL(blueprintID, archiveURL string, options BlueprintUpload) (*BlueprintGet, error) {
+	return cl.UploadBlueprintURLWithContext(context.Background(), blueprintID, archiveURL, options)
+}
+
+//UploadBlueprintURLWithContext - upload blueprint from archive url, archive
+// is downloaded by manager, canceled with context
+func (cl *Client) UploadBlueprintURLWithContext(ctx context.Context, blueprintID, archiveURL string, options BlueprintUpload) (*BlueprintGet, error) {
+	values := url.Values{}
+	values.Set("blueprint_archive_url", archiveURL)
 
 	var blueprint BlueprintGet
 
-	err := cl.PutZip("blueprints/"+blueprintID+"?application_file_name="+nameFile, []string{dirPath}, &blueprint)
+	err := cl.PutBinaryWithContext(ctx, options.uploadURL(blueprintID, values), []byte{}, &blueprint)
 	if err != nil {
 		return nil, err
 	}
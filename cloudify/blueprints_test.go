@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const blueprintUploadResponce = `{
+	"id": "blueprint",
+	"main_file_name": "main.yaml",
+	"tenant_name": "default_tenant",
+	"created_by": "admin"
+}`
+
+// TestUploadBlueprintURL - check that archive url is sent as parameter
+func TestUploadBlueprintURL(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PutResponse = []byte(blueprintUploadResponce)
+	cl := ClientFromConnection(&conn)
+
+	blueprint, err := cl.UploadBlueprintURL("blueprint", "http://example.com/blueprint.zip",
+		BlueprintUpload{ApplicationFileName: "main.yaml", Visibility: "global"})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PutURL,
+		"blueprints/blueprint?application_file_name=main.yaml&blueprint_archive_url=http%3A%2F%2Fexample.com%2Fblueprint.zip&visibility=global",
+		"Recheck url for upload: %s", conn.PutURL)
+	tests.AssertEqual(t, blueprint.MainFileName, "main.yaml",
+		"Recheck unmarshal for 'main_file_name' field '%s'", blueprint.MainFileName)
+}
+
+// TestUploadBlueprintArchive - check that archive is uploaded as is
+func TestUploadBlueprintArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blueprints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var conn tests.FakeClient
+	conn.PutResponse = []byte(blueprintUploadResponce)
+	cl := ClientFromConnection(&conn)
+
+	archivePath := filepath.Join(dir, "blueprint.tar.bz2")
+	if err := ioutil.WriteFile(archivePath, []byte("archive content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cl.UploadBlueprintArchive("blueprint", archivePath, BlueprintUpload{}); err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PutURL, "blueprints/blueprint?",
+		"Recheck url for upload: %s", conn.PutURL)
+	tests.AssertEqual(t, string(conn.PutData), "archive content",
+		"Recheck uploaded content: %s", string(conn.PutData))
+
+	if _, err := cl.UploadBlueprintArchive("blueprint", filepath.Join(dir, "blueprint.yaml"), BlueprintUpload{}); err == nil {
+		t.Error("Non archive file must be rejected")
+	}
+}
+
+// TestUploadBlueprintDir - check that directory is packed with main file name
+func TestUploadBlueprintDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blueprints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var conn tests.FakeClient
+	conn.PutResponse = []byte(blueprintUploadResponce)
+	cl := ClientFromConnection(&conn)
+
+	blueprintPath := filepath.Join(dir, "main.yaml")
+	if err := ioutil.WriteFile(blueprintPath, []byte("node_templates: {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cl.UploadBlueprintDir("blueprint", blueprintPath, BlueprintUpload{ArchiveFormat: "tar.gz"}); err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PutURL, "blueprints/blueprint?application_file_name=main.yaml",
+		"Recheck url for upload: %s", conn.PutURL)
+	// gzip magic
+	tests.AssertEqual(t, string(conn.PutData[:2]), "\x1f\x8b",
+		"Recheck archive format: %v", conn.PutData[:2])
+}
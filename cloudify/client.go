@@ -17,11 +17,14 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
-	"io/ioutil"
+	"io"
 	"net/url"
+	"os"
 )
 
 // ClientConfig - all configuration fields for connection
@@ -33,12 +36,75 @@ type ClientConfig struct {
 	AgentFile       string `json:"agent,omitempty"`
 	DeploymentsFile string `json:"deployment,omitempty"`
 	Debug           bool   `json:"debug,omitempty"`
+	// count of attempts for requests failed by transient errors
+	RetryAttempts int `json:"retry_attempts,omitempty"`
+	// api token, used instead of user/password, or session token
+	// requested by user/password
+	Token        string `json:"token,omitempty"`
+	SessionToken bool   `json:"session_token,omitempty"`
+	// tls settings
+	CACertFile      string `json:"ca_cert_file,omitempty"`
+	CACert          string `json:"ca_cert,omitempty"`
+	ClientCertFile  string `json:"client_cert_file,omitempty"`
+	ClientKeyFile   string `json:"client_key_file,omitempty"`
+	TLSServerName   string `json:"tls_server_name,omitempty"`
+	Insecure        bool   `json:"insecure,omitempty"`
+	CertFingerprint string `json:"cert_fingerprint,omitempty"`
+}
+
+// String - printable config without credentials
+func (cfg ClientConfig) String() string {
+	hidden := cfg
+	if hidden.Password != "" {
+		hidden.Password = "***"
+	}
+	if hidden.Token != "" {
+		hidden.Token = "***"
+	}
+	if hidden.CACert != "" {
+		hidden.CACert = "..."
+	}
+	// use other type for skip String() recursion
+	type config ClientConfig
+	return fmt.Sprintf("%+v", config(hidden))
+}
+
+// GetAuthenticator - authentication used for connection
+func (cfg *ClientConfig) GetAuthenticator() rest.Authenticator {
+	if cfg.Token != "" {
+		return &rest.TokenAuth{Token: cfg.Token}
+	}
+	if cfg.SessionToken {
+		return &rest.SessionTokenAuth{User: cfg.User, Password: cfg.Password}
+	}
+	return &rest.BasicAuth{User: cfg.User, Password: cfg.Password}
+}
+
+// GetTLSConfig - tls settings for connection
+func (cfg *ClientConfig) GetTLSConfig() rest.TLSConfig {
+	return rest.TLSConfig{
+		CAFile:         cfg.CACertFile,
+		CAPEM:          cfg.CACert,
+		ClientCertFile: cfg.ClientCertFile,
+		ClientKeyFile:  cfg.ClientKeyFile,
+		ServerName:     cfg.TLSServerName,
+		Insecure:       cfg.Insecure,
+		Fingerprint:    cfg.CertFingerprint,
+	}
 }
 
 //Client - struct with connection settings for connect to manager
 type Client struct {
 	ClientConfig
 	restClCache rest.ConnectionOperationsInterface
+	// authentication shared by all connections, keeps session token
+	auth        rest.Authenticator
+	retryPolicy rest.RetryPolicy
+	waiter      *ExecutionWaiter
+	// install/uninstall progress
+	lifecycleHandler LifecycleHandler
+	// binary upload/download progress
+	progressHandler ProgressHandler
 }
 
 //restCl - return client connection
@@ -48,12 +114,25 @@ func (cl *Client) restCl() rest.ConnectionOperationsInterface {
 		conn = cl.restClCache
 	} else {
 		cl.updateHostFromAgent()
-		conn = rest.NewClient(cl.Host, cl.User, cl.Password, cl.Tenant)
+		if cl.auth == nil {
+			cl.auth = cl.GetAuthenticator()
+		}
+		conn = rest.NewAuthClient(cl.Host, cl.Tenant, cl.auth, cl.GetTLSConfig())
 	}
 	conn.SetDebug(cl.Debug)
+	if cl.retryPolicy != nil {
+		conn.SetRetryPolicy(cl.retryPolicy)
+	} else if cl.RetryAttempts > 1 {
+		conn.SetRetryPolicy(rest.NewBackoffRetryPolicy(cl.RetryAttempts))
+	}
 	return conn
 }
 
+//SetRetryPolicy - use custom policy for repeat requests failed by transient errors
+func (cl *Client) SetRetryPolicy(policy rest.RetryPolicy) {
+	cl.retryPolicy = policy
+}
+
 //CacheConnection - lock connection, don't reread agent file
 func (cl *Client) CacheConnection() {
 	// already cached
@@ -96,7 +175,12 @@ func (cl *Client) GetAPIVersion() string {
 
 //Get - get cloudify object from server
 func (cl *Client) Get(url string, output rest.MessageInterface) error {
-	body, err := cl.restCl().Get(url, rest.JSONContentType)
+	return cl.GetWithContext(context.Background(), url, output)
+}
+
+//GetWithContext - get cloudify object from server, canceled with context
+func (cl *Client) GetWithContext(ctx context.Context, url string, output rest.MessageInterface) error {
+	body, err := cl.restCl().GetWithContext(ctx, url, rest.JSONContentType)
 	if err != nil {
 		return err
 	}
@@ -114,27 +198,56 @@ func (cl *Client) Get(url string, output rest.MessageInterface) error {
 
 //GetBinary - get binary object from manager without any kind of unmarshaling
 func (cl *Client) GetBinary(url, outputPath string) error {
-	body, err := cl.restCl().Get(url, rest.DataContentType)
+	return cl.GetBinaryWithContext(context.Background(), url, outputPath)
+}
+
+//GetBinaryWithContext - get binary object from manager without any kind of unmarshaling,
+// canceled with context. Object is streamed to file, partially downloaded file
+// is removed on error.
+func (cl *Client) GetBinaryWithContext(ctx context.Context, url, outputPath string) error {
+	file, err := os.OpenFile(outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
 	}
 
-	err = ioutil.WriteFile(outputPath, body, 0644)
+	err = cl.GetBinaryStreamWithContext(ctx, url, file)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
 	if err != nil {
+		os.Remove(outputPath)
 		return err
 	}
 
 	return nil
 }
 
+//GetBinaryStream - get binary object from manager and write it to output
+func (cl *Client) GetBinaryStream(url string, output io.Writer) error {
+	return cl.GetBinaryStreamWithContext(context.Background(), url, output)
+}
+
+//GetBinaryStreamWithContext - get binary object from manager and write it
+// to output, canceled with context
+func (cl *Client) GetBinaryStreamWithContext(ctx context.Context, url string, output io.Writer) error {
+	stream, size, err := cl.restCl().GetStreamWithContext(ctx, url, rest.DataContentType)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(output, cl.withProgress(stream, size))
+	return err
+}
+
 //binarySend - store/send object to manger without marshaling, response will be unmarshaled
-func binarySend(cl *Client, usePut bool, url string, input []byte, inputType string, output rest.MessageInterface) error {
+func binarySend(ctx context.Context, cl *Client, usePut bool, url string, input []byte, inputType string, output rest.MessageInterface) error {
 	var body []byte
 	var err error
 	if usePut {
-		body, err = cl.restCl().Put(url, inputType, input)
+		body, err = cl.restCl().PutWithContext(ctx, url, inputType, input)
 	} else {
-		body, err = cl.restCl().Post(url, inputType, input)
+		body, err = cl.restCl().PostWithContext(ctx, url, inputType, input)
 	}
 	if err != nil {
 		return err
@@ -153,47 +266,167 @@ func binarySend(cl *Client, usePut bool, url string, input []byte, inputType str
 
 //PutBinary - store/send binary object to manger without marshaling, response will be unmarshaled
 func (cl *Client) PutBinary(url string, data []byte, output rest.MessageInterface) error {
-	return binarySend(cl, true, url, data, rest.DataContentType, output)
+	return cl.PutBinaryWithContext(context.Background(), url, data, output)
+}
+
+//PutBinaryWithContext - store/send binary object to manger without marshaling,
+// response will be unmarshaled, canceled with context
+func (cl *Client) PutBinaryWithContext(ctx context.Context, url string, data []byte, output rest.MessageInterface) error {
+	return binarySend(ctx, cl, true, url, data, rest.DataContentType, output)
+}
+
+//binaryStreamSend - send stream to manger without marshaling, response will be unmarshaled
+func binaryStreamSend(ctx context.Context, cl *Client, usePut bool, url string, input io.Reader, size int64, inputType string, output rest.MessageInterface) error {
+	var body []byte
+	var err error
+	input = cl.withProgress(input, size)
+	if usePut {
+		body, err = cl.restCl().PutStreamWithContext(ctx, url, inputType, input, size)
+	} else {
+		body, err = cl.restCl().PostStreamWithContext(ctx, url, inputType, input, size)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, output)
+	if err != nil {
+		return err
+	}
+
+	if len(output.ErrorCode()) > 0 {
+		return output
+	}
+	return nil
+}
+
+//PutBinaryStream - store/send binary stream to manger without marshaling,
+// size is -1 if size is unknown, response will be unmarshaled
+func (cl *Client) PutBinaryStream(url string, input io.Reader, size int64, output rest.MessageInterface) error {
+	return cl.PutBinaryStreamWithContext(context.Background(), url, input, size, output)
+}
+
+//PutBinaryStreamWithContext - store/send binary stream to manger without marshaling,
+// response will be unmarshaled, canceled with context
+func (cl *Client) PutBinaryStreamWithContext(ctx context.Context, url string, input io.Reader, size int64, output rest.MessageInterface) error {
+	return binaryStreamSend(ctx, cl, true, url, input, size, rest.DataContentType, output)
+}
+
+//PostBinaryStream - send binary stream to manger without marshaling,
+// size is -1 if size is unknown, response will be unmarshaled
+func (cl *Client) PostBinaryStream(url string, input io.Reader, size int64, output rest.MessageInterface) error {
+	return cl.PostBinaryStreamWithContext(context.Background(), url, input, size, output)
+}
+
+//PostBinaryStreamWithContext - send binary stream to manger without marshaling,
+// response will be unmarshaled, canceled with context
+func (cl *Client) PostBinaryStreamWithContext(ctx context.Context, url string, input io.Reader, size int64, output rest.MessageInterface) error {
+	return binaryStreamSend(ctx, cl, false, url, input, size, rest.DataContentType, output)
+}
+
+//archiveStream - check paths and return archive stream created while it is sent
+func archiveStream(paths []string, format string, exclude []string) (io.ReadCloser, error) {
+	if !utils.CanCreateArchive(format) {
+		return nil, fmt.Errorf("Unsupported archive format: %s", format)
+	}
+	for _, path := range paths {
+		if _, err := os.Lstat(path); err != nil {
+			return nil, err
+		}
+	}
+	return utils.DirArchiveStream(paths, format, exclude), nil
 }
 
 //PutZip - store/send path as archive to manger without marshaling, response will be unmarshaled
 func (cl *Client) PutZip(url string, paths []string, output rest.MessageInterface) error {
-	data, err := utils.DirZipArchive(paths)
+	return cl.PutZipWithContext(context.Background(), url, paths, output)
+}
+
+//PutZipWithContext - store/send path as archive to manger without marshaling,
+// response will be unmarshaled, canceled with context
+func (cl *Client) PutZipWithContext(ctx context.Context, url string, paths []string, output rest.MessageInterface) error {
+	stream, err := archiveStream(paths, utils.ArchiveZip, nil)
 	if err != nil {
 		return err
 	}
+	defer stream.Close()
 
-	return binarySend(cl, true, url, data, rest.DataContentType, output)
+	return binaryStreamSend(ctx, cl, true, url, stream, -1, rest.DataContentType, output)
 }
 
 //PostZip - store/send path as archive to manger without marshaling, response will be unmarshaled
 func (cl *Client) PostZip(url string, paths []string, output rest.MessageInterface) error {
-	data, err := utils.DirZipArchive(paths)
+	return cl.PostZipWithContext(context.Background(), url, paths, output)
+}
+
+//PostZipWithContext - store/send path as archive to manger without marshaling,
+// response will be unmarshaled, canceled with context
+func (cl *Client) PostZipWithContext(ctx context.Context, url string, paths []string, output rest.MessageInterface) error {
+	stream, err := archiveStream(paths, utils.ArchiveZip, nil)
 	if err != nil {
 		return err
 	}
+	defer stream.Close()
 
-	return binarySend(cl, false, url, data, rest.DataContentType, output)
+	return binaryStreamSend(ctx, cl, false, url, stream, -1, rest.DataContentType, output)
 }
 
 //Put - send object to manager(mainly replece old one)
 func (cl *Client) Put(url string, input interface{}, output rest.MessageInterface) error {
+	return cl.PutWithContext(context.Background(), url, input, output)
+}
+
+//PutWithContext - send object to manager(mainly replece old one), canceled with context
+func (cl *Client) PutWithContext(ctx context.Context, url string, input interface{}, output rest.MessageInterface) error {
+	jsonData, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	return binarySend(ctx, cl, true, url, jsonData, rest.JSONContentType, output)
+}
+
+//Patch - send changed fields of object to manager
+func (cl *Client) Patch(url string, input interface{}, output rest.MessageInterface) error {
+	return cl.PatchWithContext(context.Background(), url, input, output)
+}
+
+//PatchWithContext - send changed fields of object to manager, canceled with context
+func (cl *Client) PatchWithContext(ctx context.Context, url string, input interface{}, output rest.MessageInterface) error {
 	jsonData, err := json.Marshal(input)
 	if err != nil {
 		return err
 	}
 
-	return binarySend(cl, true, url, jsonData, rest.JSONContentType, output)
+	body, err := cl.restCl().PatchWithContext(ctx, url, rest.JSONContentType, jsonData)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, output)
+	if err != nil {
+		return err
+	}
+
+	if len(output.ErrorCode()) > 0 {
+		return output
+	}
+	return nil
 }
 
 //Post - send cloudify object to manager
 func (cl *Client) Post(url string, input interface{}, output rest.MessageInterface) error {
+	return cl.PostWithContext(context.Background(), url, input, output)
+}
+
+//PostWithContext - send cloudify object to manager, canceled with context
+func (cl *Client) PostWithContext(ctx context.Context, url string, input interface{}, output rest.MessageInterface) error {
 	jsonData, err := json.Marshal(input)
 	if err != nil {
 		return err
 	}
 
-	body, err := cl.restCl().Post(url, rest.JSONContentType, jsonData)
+	body, err := cl.restCl().PostWithContext(ctx, url, rest.JSONContentType, jsonData)
 	if err != nil {
 		return err
 	}
@@ -211,6 +444,11 @@ func (cl *Client) Post(url string, input interface{}, output rest.MessageInterfa
 
 //Delete - delete cloudify object on manager
 func (cl *Client) Delete(url string, input interface{}, output rest.MessageInterface) error {
+	return cl.DeleteWithContext(context.Background(), url, input, output)
+}
+
+//DeleteWithContext - delete cloudify object on manager, canceled with context
+func (cl *Client) DeleteWithContext(ctx context.Context, url string, input interface{}, output rest.MessageInterface) error {
 	var jsonData = []byte{}
 	var err error
 	if input != nil {
@@ -219,7 +457,7 @@ func (cl *Client) Delete(url string, input interface{}, output rest.MessageInter
 			return err
 		}
 	}
-	body, err := cl.restCl().Delete(url, rest.JSONContentType, jsonData)
+	body, err := cl.restCl().DeleteWithContext(ctx, url, rest.JSONContentType, jsonData)
 	if err != nil {
 		return err
 	}
@@ -17,8 +17,13 @@ limitations under the License.
 package cloudify
 
 import (
+	"bytes"
 	"fmt"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -44,3 +49,104 @@ func ExampleClient() {
 	fmt.Printf("Version: %s", cl.GetAPIVersion())
 	// Output: Version: v3.1
 }
+
+// TestClientConfigString - check that credentials are hidden
+func TestClientConfigString(t *testing.T) {
+	config := ClientConfig{
+		Host:     "localhost",
+		User:     "admin",
+		Password: "password",
+		Token:    "token",
+	}
+	description := fmt.Sprintf("%v", ServiceConfig{ClientConfig: config})
+	if strings.Contains(description, "password") || strings.Contains(description, "token") {
+		t.Errorf("Recheck credentials hiding in '%s'", description)
+	}
+	if !strings.Contains(description, "admin") {
+		t.Errorf("Recheck user in '%s'", description)
+	}
+}
+
+// TestGetDeploymentNotFound - check that not found error is reported as is
+func TestGetDeploymentNotFound(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetError = &rest.APIError{Status: 404, Code: "not_found_error"}
+	cl := ClientFromConnection(&conn)
+
+	_, err := cl.GetDeployment("unknown")
+	if !rest.IsNotFound(err) {
+		t.Errorf("Recheck error reporting: %v", err)
+	}
+	tests.AssertEqual(t, conn.GetURL, "deployments/unknown",
+		"Recheck url for deployment: %s", conn.GetURL)
+}
+
+// TestBinaryStreamProgress - check that download progress is reported
+func TestBinaryStreamProgress(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte("archive content")
+	cl := ClientFromConnection(&conn)
+
+	var done, total int64
+	cl.SetProgressHandler(func(current, size int64) {
+		done = current
+		total = size
+	})
+
+	var output bytes.Buffer
+	if err := cl.GetBinaryStream("blueprints/blueprint/archive", &output); err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, output.String(), "archive content",
+		"Recheck downloaded content: %s", output.String())
+	tests.AssertEqual(t, done, int64(15), "Recheck reported progress: %d", done)
+	tests.AssertEqual(t, total, int64(15), "Recheck reported size: %d", total)
+}
+
+// TestPutZipMissedPath - check that path is checked before upload
+func TestPutZipMissedPath(t *testing.T) {
+	var conn tests.FakeClient
+	cl := ClientFromConnection(&conn)
+
+	var blueprint BlueprintGet
+	if err := cl.PutZip("blueprints/blueprint", []string{"/unknown/path"}, &blueprint); err == nil {
+		t.Error("Missed path must be reported")
+	}
+	tests.AssertEqual(t, conn.PutURL, "", "Archive must not be sent: %s", conn.PutURL)
+}
+
+// TestSessionTokenReused - check that session token is requested once for
+// all client calls
+func TestSessionTokenReused(t *testing.T) {
+	var tokenRequests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", rest.JSONContentType)
+		if r.URL.Path == "/api/v3.1/tokens" {
+			tokenRequests++
+			w.Write([]byte(`{"value": "session"}`))
+			return
+		}
+		if r.Header.Get(rest.TokenHeader) != "session" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message": "no token", "error_code": "unauthorized_error"}`))
+			return
+		}
+		w.Write([]byte(`{"id": "deployment"}`))
+	}))
+	defer ts.Close()
+
+	cl := NewClient(ClientConfig{
+		Host:         ts.URL,
+		User:         "admin",
+		Password:     "password",
+		Tenant:       "default_tenant",
+		SessionToken: true,
+	})
+	for i := 0; i < 3; i++ {
+		if _, err := cl.GetDeployment("deployment"); err != nil {
+			t.Errorf("Request with token must be accepted: %s", err.Error())
+		}
+	}
+	tests.AssertEqual(t, tokenRequests, 1, "Token must be reused, requested %d times", tokenRequests)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	"encoding/json"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 )
@@ -70,9 +71,8 @@ type ScalingGroup struct {
 
 // NodeGroup - Node group struct
 type NodeGroup struct {
-	Members []string `json:"members"`
-	// TODO use correct type for "policies" struct
-	Policies map[string]interface{} `json:"policies"`
+	Members  []string               `json:"members"`
+	Policies map[string]GroupPolicy `json:"policies"`
 }
 
 // Deployment - deployment struct
@@ -81,15 +81,13 @@ type Deployment struct {
 	rest.Resource
 	// contain information from post
 	DeploymentPost
-	Permalink     string                  `json:"permalink"`
-	Workflows     []Workflow              `json:"workflows"`
-	Outputs       map[string]interface{}  `json:"outputs"`
-	ScalingGroups map[string]ScalingGroup `json:"scaling_groups"`
-	Groups        map[string]NodeGroup    `json:"groups"`
-	// TODO use correct type for "policy_types" struct
-	PolicyTypes map[string]interface{} `json:"policy_types"`
-	// TODO use correct type for "policy_triggers" struct
-	PolicyTriggers map[string]interface{} `json:"policy_triggers"`
+	Permalink      string                   `json:"permalink"`
+	Workflows      []Workflow               `json:"workflows"`
+	Outputs        map[string]interface{}   `json:"outputs"`
+	ScalingGroups  map[string]ScalingGroup  `json:"scaling_groups"`
+	Groups         map[string]NodeGroup     `json:"groups"`
+	PolicyTypes    map[string]PolicyType    `json:"policy_types"`
+	PolicyTriggers map[string]PolicyTrigger `json:"policy_triggers"`
 }
 
 // GetJSONOutputs - get deployments outputs as json string
@@ -126,11 +124,17 @@ type Deployments struct {
 
 // GetDeployments - get deployments list from server filtered by params
 func (cl *Client) GetDeployments(params map[string]string) (*Deployments, error) {
+	return cl.GetDeploymentsWithContext(context.Background(), params)
+}
+
+// GetDeploymentsWithContext - get deployments list from server filtered by params,
+// canceled with context
+func (cl *Client) GetDeploymentsWithContext(ctx context.Context, params map[string]string) (*Deployments, error) {
 	var deployments Deployments
 
 	values := cl.stringMapToURLValue(params)
 
-	err := cl.Get("deployments?"+values.Encode(), &deployments)
+	err := cl.GetWithContext(ctx, "deployments?"+values.Encode(), &deployments)
 	if err != nil {
 		return nil, err
 	}
@@ -138,11 +142,55 @@ func (cl *Client) GetDeployments(params map[string]string) (*Deployments, error)
 	return &deployments, nil
 }
 
+// IterateDeployments - call handler for each deployment filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateDeployments(ctx context.Context, params map[string]string, handler func(Deployment) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		deployments, err := cl.GetDeploymentsWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range deployments.Items {
+			if !handler(item) {
+				return deployments.Metadata.Pagination, len(deployments.Items), false, nil
+			}
+		}
+		return deployments.Metadata.Pagination, len(deployments.Items), true, nil
+	})
+}
+
+// GetAllDeployments - return deployments from all pages filtered by params
+func (cl *Client) GetAllDeployments(params map[string]string) (*Deployments, error) {
+	return cl.GetAllDeploymentsWithContext(context.Background(), params)
+}
+
+// GetAllDeploymentsWithContext - return deployments from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllDeploymentsWithContext(ctx context.Context, params map[string]string) (*Deployments, error) {
+	var deployments Deployments
+
+	err := cl.IterateDeployments(ctx, params, func(item Deployment) bool {
+		deployments.Items = append(deployments.Items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deployments.Metadata = allItemsMetadata(len(deployments.Items))
+	return &deployments, nil
+}
+
 // DeleteDeployments - delete deployment by ID
 func (cl *Client) DeleteDeployments(deploymentID string) (*DeploymentGet, error) {
+	return cl.DeleteDeploymentsWithContext(context.Background(), deploymentID)
+}
+
+// DeleteDeploymentsWithContext - delete deployment by ID, canceled with context
+func (cl *Client) DeleteDeploymentsWithContext(ctx context.Context, deploymentID string) (*DeploymentGet, error) {
 	var deployment DeploymentGet
 
-	err := cl.Delete("deployments/"+deploymentID, nil, &deployment)
+	err := cl.DeleteWithContext(ctx, "deployments/"+deploymentID, nil, &deployment)
 	if err != nil {
 		return nil, err
 	}
@@ -152,9 +200,14 @@ func (cl *Client) DeleteDeployments(deploymentID string) (*DeploymentGet, error)
 
 // CreateDeployments - create deployment
 func (cl *Client) CreateDeployments(deploymentID string, depl DeploymentPost) (*DeploymentGet, error) {
+	return cl.CreateDeploymentsWithContext(context.Background(), deploymentID, depl)
+}
+
+// CreateDeploymentsWithContext - create deployment, canceled with context
+func (cl *Client) CreateDeploymentsWithContext(ctx context.Context, deploymentID string, depl DeploymentPost) (*DeploymentGet, error) {
 	var deployment DeploymentGet
 
-	err := cl.Put("deployments/"+deploymentID, depl, &deployment)
+	err := cl.PutWithContext(ctx, "deployments/"+deploymentID, depl, &deployment)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,236 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"fmt"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	"log"
+	"time"
+)
+
+// Deployment update states returned by manager
+const (
+	DeploymentUpdateUpdating          = "updating"
+	DeploymentUpdateExecutingWorkflow = "executing_workflow"
+	DeploymentUpdateFinalizing        = "finalizing"
+	DeploymentUpdateSuccessful        = "successful"
+	DeploymentUpdateFailed            = "failed"
+)
+
+// DeploymentUpdatePost - information for start deployment update
+type DeploymentUpdatePost struct {
+	BlueprintID string                 `json:"blueprint_id"`
+	Inputs      map[string]interface{} `json:"inputs,omitempty"`
+	// custom workflow used instead of default update workflow
+	WorkflowID    string   `json:"workflow_id,omitempty"`
+	SkipInstall   bool     `json:"skip_install"`
+	SkipUninstall bool     `json:"skip_uninstall"`
+	SkipReinstall bool     `json:"skip_reinstall"`
+	IgnoreFailure bool     `json:"ignore_failure"`
+	InstallFirst  bool     `json:"install_first"`
+	ReinstallList []string `json:"reinstall_list,omitempty"`
+	Force         bool     `json:"force"`
+}
+
+// DeploymentUpdateStep - one change in deployment made by update
+type DeploymentUpdateStep struct {
+	ID         string `json:"id"`
+	Action     string `json:"action"`
+	EntityID   string `json:"entity_id"`
+	EntityType string `json:"entity_type"`
+}
+
+// DeploymentUpdate - information about deployment update
+type DeploymentUpdate struct {
+	rest.ObjectIDWithTenant
+	DeploymentID   string                 `json:"deployment_id"`
+	State          string                 `json:"state"`
+	ExecutionID    string                 `json:"execution_id"`
+	OldBlueprintID string                 `json:"old_blueprint_id"`
+	NewBlueprintID string                 `json:"new_blueprint_id"`
+	OldInputs      map[string]interface{} `json:"old_inputs"`
+	NewInputs      map[string]interface{} `json:"new_inputs"`
+	CreatedAt      string                 `json:"created_at"`
+	Steps          []DeploymentUpdateStep `json:"steps"`
+}
+
+// DeploymentUpdateGet - response from manager about selected deployment update
+type DeploymentUpdateGet struct {
+	rest.BaseMessage
+	DeploymentUpdate
+}
+
+// DeploymentUpdates - response from manager with deployment updates list
+type DeploymentUpdates struct {
+	rest.BaseMessage
+	Metadata rest.Metadata      `json:"metadata"`
+	Items    []DeploymentUpdate `json:"items"`
+}
+
+// DeploymentUpdateWaitResult - deployment update state after wait
+type DeploymentUpdateWaitResult struct {
+	State  WaitState
+	Update DeploymentUpdate
+}
+
+// IsFinishedUpdateState - deployment update with such state will never change state again
+func IsFinishedUpdateState(state string) bool {
+	return state == DeploymentUpdateSuccessful || state == DeploymentUpdateFailed
+}
+
+// GetDeploymentUpdates - return deployment updates filtered by params
+func (cl *Client) GetDeploymentUpdates(params map[string]string) (*DeploymentUpdates, error) {
+	return cl.GetDeploymentUpdatesWithContext(context.Background(), params)
+}
+
+// GetDeploymentUpdatesWithContext - return deployment updates filtered by params,
+// canceled with context
+func (cl *Client) GetDeploymentUpdatesWithContext(ctx context.Context, params map[string]string) (*DeploymentUpdates, error) {
+	var updates DeploymentUpdates
+
+	values := cl.stringMapToURLValue(params)
+
+	err := cl.GetWithContext(ctx, "deployment-updates?"+values.Encode(), &updates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updates, nil
+}
+
+// GetDeploymentUpdate - return deployment update with steps by id
+func (cl *Client) GetDeploymentUpdate(updateID string) (*DeploymentUpdate, error) {
+	return cl.GetDeploymentUpdateWithContext(context.Background(), updateID)
+}
+
+// GetDeploymentUpdateWithContext - return deployment update with steps by id,
+// canceled with context
+func (cl *Client) GetDeploymentUpdateWithContext(ctx context.Context, updateID string) (*DeploymentUpdate, error) {
+	var update DeploymentUpdateGet
+
+	err := cl.GetWithContext(ctx, "deployment-updates/"+updateID, &update)
+	if err != nil {
+		return nil, err
+	}
+
+	return &update.DeploymentUpdate, nil
+}
+
+// UpdateDeployment - start update of deployment to blueprint with new inputs
+func (cl *Client) UpdateDeployment(deploymentID string, update DeploymentUpdatePost) (*DeploymentUpdate, error) {
+	return cl.UpdateDeploymentWithContext(context.Background(), deploymentID, update)
+}
+
+// UpdateDeploymentWithContext - start update of deployment to blueprint with new inputs,
+// canceled with context
+func (cl *Client) UpdateDeploymentWithContext(ctx context.Context, deploymentID string, update DeploymentUpdatePost) (*DeploymentUpdate, error) {
+	var started DeploymentUpdateGet
+
+	err := cl.PutWithContext(ctx, "deployment-updates/"+deploymentID+"/update/initiate", update, &started)
+	if err != nil {
+		return nil, err
+	}
+
+	return &started.DeploymentUpdate, nil
+}
+
+// UpdateDeploymentFromArchive - upload blueprint from path as new blueprint
+// and start update of deployment to it
+func (cl *Client) UpdateDeploymentFromArchive(deploymentID, blueprintPath string, update DeploymentUpdatePost) (*DeploymentUpdate, error) {
+	return cl.UpdateDeploymentFromArchiveWithContext(context.Background(), deploymentID, blueprintPath, update)
+}
+
+// UpdateDeploymentFromArchiveWithContext - upload blueprint from path and start
+// update of deployment to it, canceled with context
+func (cl *Client) UpdateDeploymentFromArchiveWithContext(ctx context.Context, deploymentID, blueprintPath string, update DeploymentUpdatePost) (*DeploymentUpdate, error) {
+	if update.BlueprintID == "" {
+		update.BlueprintID = fmt.Sprintf("%s-%d", deploymentID, time.Now().Unix())
+	}
+
+	if _, err := cl.UploadBlueprintWithContext(ctx, update.BlueprintID, blueprintPath); err != nil {
+		return nil, err
+	}
+
+	return cl.UpdateDeploymentWithContext(ctx, deploymentID, update)
+}
+
+// WaitDeploymentUpdate - wait while deployment update will be finished
+func (cl *Client) WaitDeploymentUpdate(updateID string) (*DeploymentUpdateWaitResult, error) {
+	return cl.WaitDeploymentUpdateWithContext(context.Background(), updateID)
+}
+
+// WaitDeploymentUpdateWithContext - wait while deployment update will be finished,
+// canceled with context. Uses same intervals, timeout and event handler as
+// execution waiter.
+func (cl *Client) WaitDeploymentUpdateWithContext(ctx context.Context, updateID string) (*DeploymentUpdateWaitResult, error) {
+	waiter := cl.executionWaiter()
+	waitCtx := ctx
+	if waiter.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, waiter.Timeout)
+		defer cancel()
+	}
+
+	update, err := cl.GetDeploymentUpdateWithContext(waitCtx, updateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventsOffset int
+	interval := waiter.firstInterval()
+	for !IsFinishedUpdateState(update.State) {
+		if cl.restCl().GetDebug() {
+			log.Printf("Check state for %v, last state: %v", update.ID, update.State)
+		}
+
+		err := sleepWithContext(waitCtx, interval)
+		if err == nil {
+			var current *DeploymentUpdate
+			current, err = cl.GetDeploymentUpdateWithContext(waitCtx, updateID)
+			if err == nil {
+				update = current
+				if update.ExecutionID != "" {
+					eventsOffset, err = cl.reportExecutionEvents(waitCtx, waiter, update.ExecutionID, eventsOffset)
+				}
+			}
+		}
+		if err != nil {
+			// our own timeout, parent context is still alive
+			if waitCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				return &DeploymentUpdateWaitResult{State: WaitTimedOut, Update: *update}, nil
+			}
+			return nil, err
+		}
+
+		interval = waiter.nextInterval(interval)
+	}
+
+	if update.ExecutionID != "" {
+		_, err := cl.reportFinalEvents(waitCtx, waiter, update.ExecutionID, eventsOffset)
+		// update is finished, our own timeout only stops events report
+		if err != nil && (waitCtx.Err() != context.DeadlineExceeded || ctx.Err() != nil) {
+			return nil, err
+		}
+	}
+
+	if update.State == DeploymentUpdateFailed {
+		return &DeploymentUpdateWaitResult{State: WaitFailed, Update: *update}, nil
+	}
+	return &DeploymentUpdateWaitResult{State: WaitTerminated, Update: *update}, nil
+}
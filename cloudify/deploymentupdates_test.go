@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"testing"
+)
+
+const deploymentUpdateResponce = `{
+	"id": "deployment-1234",
+	"deployment_id": "deployment",
+	"state": "successful",
+	"old_blueprint_id": "blueprint",
+	"new_blueprint_id": "blueprint-new",
+	"steps": [{
+		"id": "add_node_vm",
+		"action": "add",
+		"entity_id": "nodes:vm",
+		"entity_type": "node"
+	}]
+}`
+
+// TestUpdateDeployment - check request for start update and wait result
+func TestUpdateDeployment(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PutResponse = []byte(deploymentUpdateResponce)
+	conn.GetResponse = []byte(deploymentUpdateResponce)
+	cl := ClientFromConnection(&conn)
+
+	update, err := cl.UpdateDeployment("deployment", DeploymentUpdatePost{
+		BlueprintID: "blueprint-new", SkipInstall: true,
+	})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	// update with existed blueprint is PUT, POST uploads blueprint archive
+	tests.AssertEqual(t, conn.PostURL, "",
+		"Update must not be posted: %s", conn.PostURL)
+	tests.AssertEqual(t, conn.PutURL, "deployment-updates/deployment/update/initiate",
+		"Recheck url for update: %s", conn.PutURL)
+	tests.AssertEqual(t, string(conn.PutData),
+		`{"blueprint_id":"blueprint-new","skip_install":true,"skip_uninstall":false,`+
+			`"skip_reinstall":false,"ignore_failure":false,"install_first":false,"force":false}`,
+		"Recheck update request: %s", string(conn.PutData))
+
+	result, err := cl.WaitDeploymentUpdate(update.ID)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.GetURL, "deployment-updates/deployment-1234",
+		"Recheck url for update: %s", conn.GetURL)
+	tests.AssertEqual(t, result.State, WaitTerminated,
+		"Recheck wait state: %s", result.State)
+	tests.AssertEqual(t, len(result.Update.Steps), 1,
+		"Recheck update steps: %v", result.Update.Steps)
+}
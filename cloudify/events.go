@@ -17,6 +17,7 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 )
 
@@ -35,6 +36,7 @@ type Event struct {
 	ExecutionID       string `json:"execution_id"`
 	Timestamp         string `json:"timestamp"`
 	Message           string `json:"message"`
+	Level             string `json:"level"`
 }
 
 // Events - cloudify response with events list
@@ -46,14 +48,58 @@ type Events struct {
 
 // GetEvents - get events list filtered by params
 func (cl *Client) GetEvents(params map[string]string) (*Events, error) {
+	return cl.GetEventsWithContext(context.Background(), params)
+}
+
+// GetEventsWithContext - get events list filtered by params, canceled with context
+func (cl *Client) GetEventsWithContext(ctx context.Context, params map[string]string) (*Events, error) {
 	var events Events
 
 	values := cl.stringMapToURLValue(params)
 
-	err := cl.Get("events?"+values.Encode(), &events)
+	err := cl.GetWithContext(ctx, "events?"+values.Encode(), &events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &events, nil
+}
+
+// IterateEvents - call handler for each event filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateEvents(ctx context.Context, params map[string]string, handler func(Event) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		events, err := cl.GetEventsWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range events.Items {
+			if !handler(item) {
+				return events.Metadata.Pagination, len(events.Items), false, nil
+			}
+		}
+		return events.Metadata.Pagination, len(events.Items), true, nil
+	})
+}
+
+// GetAllEvents - return events from all pages filtered by params
+func (cl *Client) GetAllEvents(params map[string]string) (*Events, error) {
+	return cl.GetAllEventsWithContext(context.Background(), params)
+}
+
+// GetAllEventsWithContext - return events from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllEventsWithContext(ctx context.Context, params map[string]string) (*Events, error) {
+	var events Events
+
+	err := cl.IterateEvents(ctx, params, func(item Event) bool {
+		events.Items = append(events.Items, item)
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	events.Metadata = allItemsMetadata(len(events.Items))
 	return &events, nil
 }
@@ -17,7 +17,9 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 	"log"
@@ -65,8 +67,9 @@ type Execution struct {
 
 // ExecutionGet - response from manager about selected execution
 type ExecutionGet struct {
-	// can be response from api
-	rest.BaseMessage
+	// can be response from api, without int status field
+	// because execution has own string status
+	rest.CommonMessage
 	Execution
 }
 
@@ -80,11 +83,16 @@ type Executions struct {
 // GetExecutions - return list of execution on manager
 // NOTE: change params type if you want use non uniq values in params
 func (cl *Client) GetExecutions(params map[string]string) (*Executions, error) {
+	return cl.GetExecutionsWithContext(context.Background(), params)
+}
+
+// GetExecutionsWithContext - return list of execution on manager, canceled with context
+func (cl *Client) GetExecutionsWithContext(ctx context.Context, params map[string]string) (*Executions, error) {
 	var executions Executions
 
 	values := cl.stringMapToURLValue(params)
 
-	err := cl.Get("executions?"+values.Encode(), &executions)
+	err := cl.GetWithContext(ctx, "executions?"+values.Encode(), &executions)
 	if err != nil {
 		return nil, err
 	}
@@ -92,13 +100,137 @@ func (cl *Client) GetExecutions(params map[string]string) (*Executions, error) {
 	return &executions, nil
 }
 
+// IterateExecutions - call handler for each execution filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateExecutions(ctx context.Context, params map[string]string, handler func(Execution) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		executions, err := cl.GetExecutionsWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range executions.Items {
+			if !handler(item) {
+				return executions.Metadata.Pagination, len(executions.Items), false, nil
+			}
+		}
+		return executions.Metadata.Pagination, len(executions.Items), true, nil
+	})
+}
+
+// GetAllExecutions - return executions from all pages filtered by params
+func (cl *Client) GetAllExecutions(params map[string]string) (*Executions, error) {
+	return cl.GetAllExecutionsWithContext(context.Background(), params)
+}
+
+// GetAllExecutionsWithContext - return executions from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllExecutionsWithContext(ctx context.Context, params map[string]string) (*Executions, error) {
+	var executions Executions
+
+	err := cl.IterateExecutions(ctx, params, func(item Execution) bool {
+		executions.Items = append(executions.Items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	executions.Metadata = allItemsMetadata(len(executions.Items))
+	return &executions, nil
+}
+
+// Actions for change execution state
+const (
+	ExecutionActionCancel      = "cancel"
+	ExecutionActionForceCancel = "force-cancel"
+	ExecutionActionKill        = "kill"
+	ExecutionActionResume      = "resume"
+	ExecutionActionForceResume = "force-resume"
+)
+
+// ExecutionAction - request for change execution state
+type ExecutionAction struct {
+	Action string `json:"action"`
+}
+
+// GetExecution - return execution by id
+func (cl *Client) GetExecution(executionID string) (*Execution, error) {
+	return cl.GetExecutionWithContext(context.Background(), executionID)
+}
+
+// GetExecutionWithContext - return execution by id, canceled with context
+func (cl *Client) GetExecutionWithContext(ctx context.Context, executionID string) (*Execution, error) {
+	var execution ExecutionGet
+
+	err := cl.GetWithContext(ctx, "executions/"+executionID, &execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution.Execution, nil
+}
+
+// PostExecutionAction - send action to execution
+func (cl *Client) PostExecutionAction(executionID, action string) (*Execution, error) {
+	return cl.PostExecutionActionWithContext(context.Background(), executionID, action)
+}
+
+// PostExecutionActionWithContext - send action to execution, canceled with context
+func (cl *Client) PostExecutionActionWithContext(ctx context.Context, executionID, action string) (*Execution, error) {
+	var execution ExecutionGet
+
+	err := cl.PostWithContext(ctx, "executions/"+executionID,
+		ExecutionAction{Action: action}, &execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution.Execution, nil
+}
+
+// CancelExecution - cancel execution, force cancel if force is set and
+// kill all execution processes if kill is set
+func (cl *Client) CancelExecution(executionID string, force, kill bool) (*Execution, error) {
+	return cl.CancelExecutionWithContext(context.Background(), executionID, force, kill)
+}
+
+// CancelExecutionWithContext - cancel execution, canceled with context
+func (cl *Client) CancelExecutionWithContext(ctx context.Context, executionID string, force, kill bool) (*Execution, error) {
+	action := ExecutionActionCancel
+	if kill {
+		action = ExecutionActionKill
+	} else if force {
+		action = ExecutionActionForceCancel
+	}
+	return cl.PostExecutionActionWithContext(ctx, executionID, action)
+}
+
+// ResumeExecution - resume failed or cancelled execution, force resume if force is set
+func (cl *Client) ResumeExecution(executionID string, force bool) (*Execution, error) {
+	return cl.ResumeExecutionWithContext(context.Background(), executionID, force)
+}
+
+// ResumeExecutionWithContext - resume failed or cancelled execution, canceled with context
+func (cl *Client) ResumeExecutionWithContext(ctx context.Context, executionID string, force bool) (*Execution, error) {
+	action := ExecutionActionResume
+	if force {
+		action = ExecutionActionForceResume
+	}
+	return cl.PostExecutionActionWithContext(ctx, executionID, action)
+}
+
 // PostExecution - run executions without waiting
 func (cl *Client) PostExecution(exec ExecutionPost) (*ExecutionGet, error) {
+	return cl.PostExecutionWithContext(context.Background(), exec)
+}
+
+// PostExecutionWithContext - run executions without waiting, canceled with context
+func (cl *Client) PostExecutionWithContext(ctx context.Context, exec ExecutionPost) (*ExecutionGet, error) {
 	var execution ExecutionGet
 
 	var err error
 
-	err = cl.Post("executions", exec, &execution)
+	err = cl.PostWithContext(ctx, "executions", exec, &execution)
 	if err != nil {
 		return nil, err
 	}
@@ -106,25 +238,51 @@ func (cl *Client) PostExecution(exec ExecutionPost) (*ExecutionGet, error) {
 	return &execution, nil
 }
 
+// sleepWithContext - wait some time or return error if context is done before
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // WaitBeforeRunExecution - wait while all other executions will be finished
 func (cl *Client) WaitBeforeRunExecution(deploymentID string) error {
-	for true {
+	return cl.WaitBeforeRunExecutionWithContext(context.Background(), deploymentID)
+}
+
+// WaitBeforeRunExecutionWithContext - wait while all other executions will be finished,
+// canceled with context
+func (cl *Client) WaitBeforeRunExecutionWithContext(ctx context.Context, deploymentID string) error {
+	waiter := cl.executionWaiter()
+	waitCtx := ctx
+	if waiter.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, waiter.Timeout)
+		defer cancel()
+	}
+
+	interval := waiter.firstInterval()
+	for {
 		var params = map[string]string{}
 		params["deployment_id"] = deploymentID
-		executions, err := cl.GetExecutions(params)
+		executions, err := cl.GetAllExecutionsWithContext(waitCtx, params)
 		if err != nil {
 			return err
 		}
 		haveUnfinished := false
 		for _, execution := range executions.Items {
-			if execution.WorkflowID == "create_deployment_environment" && execution.Status == "failed" {
-				return fmt.Errorf(execution.ErrorMessage)
+			if execution.WorkflowID == "create_deployment_environment" && execution.Status == ExecutionFailed {
+				return errors.New(execution.ErrorMessage)
 			}
-			if execution.Status == "pending" || execution.Status == "started" || execution.Status == "cancelling" {
+			if IsActiveStatus(execution.Status) {
 				if cl.restCl().GetDebug() {
 					log.Printf("Check status for %v, last status: %v", execution.ID, execution.Status)
 				}
-				time.Sleep(15 * time.Second)
 				haveUnfinished = true
 				break
 			}
@@ -132,37 +290,46 @@ func (cl *Client) WaitBeforeRunExecution(deploymentID string) error {
 		if !haveUnfinished {
 			return nil
 		}
+		if err := sleepWithContext(waitCtx, interval); err != nil {
+			return err
+		}
+		interval = waiter.nextInterval(interval)
 	}
-	return nil
 }
 
 // RunExecution - Run executions and wait results
 // execPost: executions description for run
 // fullFinish: wait to full finish
 func (cl *Client) RunExecution(execPost ExecutionPost, fullFinish bool) (*Execution, error) {
-	var execution Execution
-	executionGet, err := cl.PostExecution(execPost)
+	return cl.RunExecutionWithContext(context.Background(), execPost, fullFinish)
+}
+
+// RunExecutionWithContext - Run executions and wait results, canceled with context
+// execPost: executions description for run
+// fullFinish: wait to full finish
+func (cl *Client) RunExecutionWithContext(ctx context.Context, execPost ExecutionPost, fullFinish bool) (*Execution, error) {
+	result, err := cl.RunExecutionWaitWithContext(ctx, execPost, fullFinish)
 	if err != nil {
 		return nil, err
 	}
-	execution = executionGet.Execution
-	for execution.Status == "pending" || (execution.Status == "started" && fullFinish) {
-		if cl.restCl().GetDebug() {
-			log.Printf("Check status for %v, last status: %v", execution.ID, execution.Status)
-		}
+	if result.State == WaitTimedOut {
+		return nil, fmt.Errorf("Timed out waiting for execution %s, last status: %s",
+			result.Execution.ID, result.Execution.Status)
+	}
+	return &result.Execution, nil
+}
 
-		time.Sleep(15 * time.Second)
+// RunExecutionWait - Run executions and return wait result with final state
+func (cl *Client) RunExecutionWait(execPost ExecutionPost, fullFinish bool) (*WaitResult, error) {
+	return cl.RunExecutionWaitWithContext(context.Background(), execPost, fullFinish)
+}
 
-		var params = map[string]string{}
-		params["id"] = execution.ID
-		executions, err := cl.GetExecutions(params)
-		if err != nil {
-			return nil, err
-		}
-		if len(executions.Items) != 1 {
-			return nil, fmt.Errorf("returned wrong count of results")
-		}
-		execution = executions.Items[0]
+// RunExecutionWaitWithContext - Run executions and return wait result with final state,
+// canceled with context
+func (cl *Client) RunExecutionWaitWithContext(ctx context.Context, execPost ExecutionPost, fullFinish bool) (*WaitResult, error) {
+	executionGet, err := cl.PostExecutionWithContext(ctx, execPost)
+	if err != nil {
+		return nil, err
 	}
-	return &execution, nil
+	return cl.waitExecution(ctx, cl.executionWaiter(), executionGet.Execution, fullFinish)
 }
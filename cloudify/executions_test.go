@@ -0,0 +1,120 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"testing"
+	"time"
+)
+
+const executionsStartedResponce = `{
+	"items": [{
+		"id": "c7bcf1c5-4dc0-4e5c-8a2e-d4a6b0d9f7a1",
+		"workflow_id": "install",
+		"deployment_id": "deployment",
+		"blueprint_id": "blueprint",
+		"status": "started",
+		"is_system_workflow": false,
+		"error": "",
+		"parameters": {}
+	}],
+	"metadata": {
+		"pagination": {
+			"total": 1,
+			"offset": 0,
+			"size": 100
+		}
+	}
+}`
+
+// TestWaitBeforeRunExecutionWithContext - check that wait loop stops by context
+func TestWaitBeforeRunExecutionWithContext(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(executionsStartedResponce)
+	conn.GetError = nil
+	cl := ClientFromConnection(&conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := cl.WaitBeforeRunExecutionWithContext(ctx, "deployment")
+	tests.AssertEqual(t, err, context.DeadlineExceeded,
+		"Recheck context deadline in wait loop: %v", err)
+	tests.AssertEqual(t, conn.GetURL, "executions?_offset=0&_size=100&deployment_id=deployment",
+		"Recheck url for executions: %s", conn.GetURL)
+}
+
+// TestGetExecutionsWithContext - check that canceled context stops request
+func TestGetExecutionsWithContext(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(executionsStartedResponce)
+	cl := ClientFromConnection(&conn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := cl.GetExecutionsWithContext(ctx, map[string]string{})
+	tests.AssertEqual(t, err, context.Canceled,
+		"Recheck canceled context: %v", err)
+
+	executions, err := cl.GetExecutions(map[string]string{})
+	if err != nil {
+		t.Error("Recheck error reporting")
+	}
+	tests.AssertEqual(t, executions.Items[0].Status, "started",
+		"Recheck unmarshal for 'status' field '%s'", executions.Items[0].Status)
+}
+
+// TestCancelExecution - check action sent for cancel/resume calls
+func TestCancelExecution(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PostResponse = []byte(executionFailedResponce)
+	conn.GetResponse = []byte(executionFailedResponce)
+	cl := ClientFromConnection(&conn)
+
+	execution, err := cl.GetExecution("c7bcf1c5")
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.GetURL, "executions/c7bcf1c5",
+		"Recheck url for execution: %s", conn.GetURL)
+	tests.AssertEqual(t, execution.Status, ExecutionFailed,
+		"Recheck execution status: %s", execution.Status)
+
+	var actions = []struct {
+		call   func() (*Execution, error)
+		action string
+	}{
+		{func() (*Execution, error) { return cl.CancelExecution("c7bcf1c5", false, false) }, `{"action":"cancel"}`},
+		{func() (*Execution, error) { return cl.CancelExecution("c7bcf1c5", true, false) }, `{"action":"force-cancel"}`},
+		{func() (*Execution, error) { return cl.CancelExecution("c7bcf1c5", true, true) }, `{"action":"kill"}`},
+		{func() (*Execution, error) { return cl.ResumeExecution("c7bcf1c5", false) }, `{"action":"resume"}`},
+		{func() (*Execution, error) { return cl.ResumeExecution("c7bcf1c5", true) }, `{"action":"force-resume"}`},
+	}
+	for _, action := range actions {
+		if _, err := action.call(); err != nil {
+			t.Errorf("Recheck error reporting: %v", err)
+		}
+		tests.AssertEqual(t, conn.PostURL, "executions/c7bcf1c5",
+			"Recheck url for execution: %s", conn.PostURL)
+		tests.AssertEqual(t, string(conn.PostData), action.action,
+			"Recheck action: %s", string(conn.PostData))
+	}
+}
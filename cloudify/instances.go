@@ -17,8 +17,11 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	"time"
 )
 
 // NodeInstanceScalingGroup - short information(ID+Name) about scaling group related to instance
@@ -27,10 +30,17 @@ type NodeInstanceScalingGroup struct {
 	ID   string `json:"id,omitempty"`
 }
 
+// NodeInstanceRelationship - relationship from instance to target instance
+type NodeInstanceRelationship struct {
+	Type       string `json:"type"`
+	TargetID   string `json:"target_id"`
+	TargetName string `json:"target_name"`
+}
+
 // NodeInstance - cloudify node instance struct
 type NodeInstance struct {
 	rest.ObjectIDWithTenant
-	Relationships     []interface{}              `json:"relationships,omitempty"`
+	Relationships     []NodeInstanceRelationship `json:"relationships,omitempty"`
 	RuntimeProperties map[string]interface{}     `json:"runtime_properties,omitempty"`
 	State             string                     `json:"state,omitempty"`
 	Version           int                        `json:"version,omitempty"`
@@ -53,7 +63,7 @@ func (instance *NodeInstance) GetProperty(name string) interface{} {
 }
 
 // GetStringProperty - return field value as string
-// or empty if field does not exist
+// or empty if field does not exist, see GetStringPath for nested values
 func (instance *NodeInstance) GetStringProperty(name string) string {
 	v := instance.GetProperty(name)
 	if v != nil {
@@ -76,6 +86,27 @@ func (instance *NodeInstance) GetJSONRuntimeProperties() (string, error) {
 	return string(jsonData), nil
 }
 
+// NodeInstanceGet - cloudify manager response with one instance
+type NodeInstanceGet struct {
+	rest.BaseMessage
+	NodeInstance
+}
+
+// NodeInstancePatch - new runtime properties of instance, version must be
+// same as current instance version on manager
+type NodeInstancePatch struct {
+	RuntimeProperties map[string]interface{} `json:"runtime_properties"`
+	Version           int                    `json:"version"`
+}
+
+// NodeInstanceUpdateAttempts - count of attempts to save runtime properties
+// in ModifyNodeInstance if instance was changed by somebody else
+const NodeInstanceUpdateAttempts = 5
+
+// NodeInstanceUpdateDelay - delay before second attempt to save runtime
+// properties, doubled on each next attempt
+const NodeInstanceUpdateDelay = 50 * time.Millisecond
+
 // NodeInstances - cloudify manager response with list instances
 type NodeInstances struct {
 	rest.BaseMessage
@@ -85,11 +116,16 @@ type NodeInstances struct {
 
 // GetNodeInstances - Get all node instances
 func (cl *Client) GetNodeInstances(params map[string]string) (*NodeInstances, error) {
+	return cl.GetNodeInstancesWithContext(context.Background(), params)
+}
+
+// GetNodeInstancesWithContext - get all node instances, canceled with context
+func (cl *Client) GetNodeInstancesWithContext(ctx context.Context, params map[string]string) (*NodeInstances, error) {
 	var instances NodeInstances
 
 	values := cl.stringMapToURLValue(params)
 
-	err := cl.Get("node-instances?"+values.Encode(), &instances)
+	err := cl.GetWithContext(ctx, "node-instances?"+values.Encode(), &instances)
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +133,136 @@ func (cl *Client) GetNodeInstances(params map[string]string) (*NodeInstances, er
 	return &instances, nil
 }
 
+// IterateNodeInstances - call handler for each node instance filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateNodeInstances(ctx context.Context, params map[string]string, handler func(NodeInstance) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		instances, err := cl.GetNodeInstancesWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range instances.Items {
+			if !handler(item) {
+				return instances.Metadata.Pagination, len(instances.Items), false, nil
+			}
+		}
+		return instances.Metadata.Pagination, len(instances.Items), true, nil
+	})
+}
+
+// GetAllNodeInstances - return node instances from all pages filtered by params
+func (cl *Client) GetAllNodeInstances(params map[string]string) (*NodeInstances, error) {
+	return cl.GetAllNodeInstancesWithContext(context.Background(), params)
+}
+
+// GetAllNodeInstancesWithContext - return node instances from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllNodeInstancesWithContext(ctx context.Context, params map[string]string) (*NodeInstances, error) {
+	var instances NodeInstances
+
+	err := cl.IterateNodeInstances(ctx, params, func(item NodeInstance) bool {
+		instances.Items = append(instances.Items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instances.Metadata = allItemsMetadata(len(instances.Items))
+	return &instances, nil
+}
+
+// GetNodeInstance - return node instance by id
+func (cl *Client) GetNodeInstance(id string) (*NodeInstance, error) {
+	return cl.GetNodeInstanceWithContext(context.Background(), id)
+}
+
+// GetNodeInstanceWithContext - return node instance by id, canceled with context
+func (cl *Client) GetNodeInstanceWithContext(ctx context.Context, id string) (*NodeInstance, error) {
+	var instance NodeInstanceGet
+
+	err := cl.GetWithContext(ctx, "node-instances/"+id, &instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance.NodeInstance, nil
+}
+
+// UpdateNodeInstance - replace runtime properties of instance, version is
+// version of instance used for build new properties. Manager returns
+// conflict error (check by rest.IsConflict) if instance has other version.
+func (cl *Client) UpdateNodeInstance(id string, runtimeProps map[string]interface{}, version int) (*NodeInstance, error) {
+	return cl.UpdateNodeInstanceWithContext(context.Background(), id, runtimeProps, version)
+}
+
+// UpdateNodeInstanceWithContext - replace runtime properties of instance,
+// canceled with context
+func (cl *Client) UpdateNodeInstanceWithContext(ctx context.Context, id string, runtimeProps map[string]interface{}, version int) (*NodeInstance, error) {
+	if runtimeProps == nil {
+		runtimeProps = map[string]interface{}{}
+	}
+	var instance NodeInstanceGet
+
+	err := cl.PatchWithContext(ctx, "node-instances/"+id, NodeInstancePatch{
+		RuntimeProperties: runtimeProps,
+		Version:           version,
+	}, &instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instance.NodeInstance, nil
+}
+
+// ModifyNodeInstance - read instance, change runtime properties by modify and
+// save them. If instance was changed by somebody else after read, modify is
+// called again with fresh properties after growing delay, up to
+// NodeInstanceUpdateAttempts times. Error from modify stops update and is returned as is.
+func (cl *Client) ModifyNodeInstance(id string, modify func(runtimeProps map[string]interface{}) error) (*NodeInstance, error) {
+	return cl.ModifyNodeInstanceWithContext(context.Background(), id, modify)
+}
+
+// ModifyNodeInstanceWithContext - read-modify-write of instance runtime
+// properties, canceled with context
+func (cl *Client) ModifyNodeInstanceWithContext(ctx context.Context, id string, modify func(runtimeProps map[string]interface{}) error) (*NodeInstance, error) {
+	var lastErr error
+	delay := NodeInstanceUpdateDelay
+	for attempt := 0; attempt < NodeInstanceUpdateAttempts; attempt++ {
+		if attempt > 0 {
+			// give other writer time to finish
+			if err := sleepWithContext(ctx, delay); err != nil {
+				return nil, err
+			}
+			delay *= 2
+		}
+
+		instance, err := cl.GetNodeInstanceWithContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		runtimeProps := instance.RuntimeProperties
+		if runtimeProps == nil {
+			runtimeProps = map[string]interface{}{}
+		}
+		if err := modify(runtimeProps); err != nil {
+			return nil, err
+		}
+
+		updated, err := cl.UpdateNodeInstanceWithContext(ctx, id, runtimeProps, instance.Version)
+		if err == nil {
+			return updated, nil
+		}
+		if !rest.IsConflict(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("Node instance %s is not updated after %d attempts: %s",
+		id, NodeInstanceUpdateAttempts, lastErr.Error())
+}
+
 // AllAreStarted - check that all instances in list are started
 func (ni *NodeInstances) AllAreStarted() bool {
 	// check that all nodes on same hostID started
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"fmt"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"testing"
+)
+
+// conflictClient - fake client which returns conflict for first patch calls
+// and new instance version on each get
+type conflictClient struct {
+	tests.FakeClient
+	conflicts int
+	version   int
+}
+
+func (cl *conflictClient) GetWithContext(ctx context.Context, url, acceptedContentType string) ([]byte, error) {
+	cl.version++
+	cl.GetResponse = []byte(fmt.Sprintf(
+		`{"id": "vm_x4d5w2", "version": %d, "runtime_properties": {"ip": "10.0.0.%d"}}`,
+		cl.version, cl.version))
+	return cl.FakeClient.GetWithContext(ctx, url, acceptedContentType)
+}
+
+func (cl *conflictClient) PatchWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	if cl.conflicts > 0 {
+		cl.conflicts--
+		cl.PatchError = &rest.APIError{Status: 409, Code: "conflict_error"}
+	} else {
+		cl.PatchError = nil
+	}
+	return cl.FakeClient.PatchWithContext(ctx, url, providedContentType, data)
+}
+
+// TestUpdateNodeInstance - check request for update runtime properties
+func TestUpdateNodeInstance(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(`{"id": "vm_x4d5w2", "version": 3, "runtime_properties": {"ip": "10.0.0.1"}}`)
+	conn.PatchResponse = []byte(`{"id": "vm_x4d5w2", "version": 4, "runtime_properties": {"name": "node-1"}}`)
+	cl := ClientFromConnection(&conn)
+
+	instance, err := cl.GetNodeInstance("vm_x4d5w2")
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.GetURL, "node-instances/vm_x4d5w2",
+		"Recheck url for instance: %s", conn.GetURL)
+	tests.AssertEqual(t, instance.Version, 3,
+		"Recheck instance version: %d", instance.Version)
+
+	instance, err = cl.UpdateNodeInstance("vm_x4d5w2", map[string]interface{}{"name": "node-1"}, 3)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PatchURL, "node-instances/vm_x4d5w2",
+		"Recheck url for instance: %s", conn.PatchURL)
+	tests.AssertEqual(t, string(conn.PatchData),
+		`{"runtime_properties":{"name":"node-1"},"version":3}`,
+		"Recheck patch request: %s", string(conn.PatchData))
+	tests.AssertEqual(t, instance.Version, 4,
+		"Recheck instance version: %d", instance.Version)
+
+	conn.PatchError = &rest.APIError{Status: 409, Code: "conflict_error"}
+	if _, err := cl.UpdateNodeInstance("vm_x4d5w2", nil, 3); !rest.IsConflict(err) {
+		t.Errorf("Conflict must be returned: %v", err)
+	}
+	tests.AssertEqual(t, string(conn.PatchData),
+		`{"runtime_properties":{},"version":3}`,
+		"Recheck patch request: %s", string(conn.PatchData))
+}
+
+// TestModifyNodeInstance - check retry of update on version conflict
+func TestModifyNodeInstance(t *testing.T) {
+	conn := conflictClient{conflicts: 2}
+	conn.PatchResponse = []byte(`{"id": "vm_x4d5w2", "version": 4}`)
+	cl := ClientFromConnection(&conn)
+
+	calls := 0
+	_, err := cl.ModifyNodeInstance("vm_x4d5w2", func(runtimeProps map[string]interface{}) error {
+		calls++
+		runtimeProps["name"] = "node-1"
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, calls, 3, "Recheck modify calls: %d", calls)
+	tests.AssertEqual(t, string(conn.PatchData),
+		`{"runtime_properties":{"ip":"10.0.0.3","name":"node-1"},"version":3}`,
+		"Recheck patch request: %s", string(conn.PatchData))
+
+	conn = conflictClient{conflicts: NodeInstanceUpdateAttempts}
+	cl = ClientFromConnection(&conn)
+	if _, err := cl.ModifyNodeInstance("vm_x4d5w2", func(runtimeProps map[string]interface{}) error {
+		return nil
+	}); err == nil {
+		t.Error("Error must be returned after all attempts")
+	}
+
+	conn = conflictClient{}
+	cl = ClientFromConnection(&conn)
+	modifyErr := fmt.Errorf("no changes")
+	if _, err := cl.ModifyNodeInstance("vm_x4d5w2", func(runtimeProps map[string]interface{}) error {
+		return modifyErr
+	}); err != modifyErr {
+		t.Errorf("Modify error must be returned: %v", err)
+	}
+	tests.AssertEqual(t, conn.PatchURL, "", "Instance must not be updated: %s", conn.PatchURL)
+
+	conn = conflictClient{conflicts: NodeInstanceUpdateAttempts}
+	cl = ClientFromConnection(&conn)
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	if _, err := cl.ModifyNodeInstanceWithContext(ctx, "vm_x4d5w2", func(runtimeProps map[string]interface{}) error {
+		calls++
+		// canceled before delay between attempts
+		cancel()
+		return nil
+	}); err != context.Canceled {
+		t.Errorf("Context error must be returned: %v", err)
+	}
+	tests.AssertEqual(t, calls, 1, "Recheck modify calls: %d", calls)
+}
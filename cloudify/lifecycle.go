@@ -0,0 +1,213 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"fmt"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	"log"
+)
+
+// Steps reported by install/uninstall calls
+const (
+	StepUploadBlueprint  = "upload blueprint"
+	StepCreateDeployment = "create deployment"
+	StepInstall          = "install"
+	StepUninstall        = "uninstall"
+	StepDeleteDeployment = "delete deployment"
+	StepDeleteBlueprint  = "delete blueprint"
+	StepCleanup          = "cleanup"
+	StepScale            = "scale"
+)
+
+// LifecycleHandler - called for each progress message of install/uninstall
+type LifecycleHandler func(step, message string)
+
+// LifecycleError - install/uninstall failed on step
+type LifecycleError struct {
+	Step string
+	Err  error
+	// execution result, if failed on workflow run
+	Result *WaitResult
+}
+
+// Error - text representation of error
+func (e *LifecycleError) Error() string {
+	return fmt.Sprintf("%s failed: %s", e.Step, e.Err.Error())
+}
+
+// SetLifecycleHandler - set handler for install/uninstall progress messages
+func (cl *Client) SetLifecycleHandler(handler LifecycleHandler) {
+	cl.lifecycleHandler = handler
+}
+
+// reportStep - send progress message to handler or to log in debug mode
+func (cl *Client) reportStep(step, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if cl.lifecycleHandler != nil {
+		cl.lifecycleHandler(step, message)
+	} else if cl.restCl().GetDebug() {
+		log.Printf("%s: %s", step, message)
+	}
+}
+
+// runWorkflow - run workflow on deployment and wait for full finish,
+// return error if execution is not successfully finished
+func (cl *Client) runWorkflow(ctx context.Context, step, deploymentID, workflowID string, parameters map[string]interface{}) (*WaitResult, error) {
+	if err := cl.WaitBeforeRunExecutionWithContext(ctx, deploymentID); err != nil {
+		return nil, &LifecycleError{Step: step, Err: err}
+	}
+
+	var exec ExecutionPost
+	exec.WorkflowID = workflowID
+	exec.DeploymentID = deploymentID
+	exec.Parameters = parameters
+	if exec.Parameters == nil {
+		exec.Parameters = map[string]interface{}{}
+	}
+
+	cl.reportStep(step, "run %s workflow on %s", workflowID, deploymentID)
+	result, err := cl.RunExecutionWaitWithContext(ctx, exec, true)
+	if err != nil {
+		return nil, &LifecycleError{Step: step, Err: err}
+	}
+	if result.State != WaitTerminated {
+		err := fmt.Errorf("execution %s %s", result.Execution.ID, result.State)
+		if result.Execution.ErrorMessage != "" {
+			err = fmt.Errorf("execution %s %s: %s", result.Execution.ID,
+				result.State, result.Execution.ErrorMessage)
+		}
+		return result, &LifecycleError{Step: step, Err: err, Result: result}
+	}
+	cl.reportStep(step, "execution %s %s", result.Execution.ID, result.State)
+	return result, nil
+}
+
+// cleanupInstall - remove deployment and blueprint created by failed install
+func (cl *Client) cleanupInstall(ctx context.Context, blueprintID, deploymentID string) {
+	if deploymentID != "" {
+		cl.reportStep(StepCleanup, "delete deployment %s", deploymentID)
+		if err := cl.deleteDeploymentAndWait(ctx, deploymentID); err != nil {
+			cl.reportStep(StepCleanup, "can't delete deployment %s: %s", deploymentID, err.Error())
+			// blueprint can't be deleted with existed deployment
+			return
+		}
+	}
+	cl.reportStep(StepCleanup, "delete blueprint %s", blueprintID)
+	if _, err := cl.DeleteBlueprintsWithContext(ctx, blueprintID); err != nil {
+		cl.reportStep(StepCleanup, "can't delete blueprint %s: %s", blueprintID, err.Error())
+	}
+}
+
+// deleteDeploymentAndWait - delete deployment and wait while manager
+// removes deployment environment
+func (cl *Client) deleteDeploymentAndWait(ctx context.Context, deploymentID string) error {
+	if _, err := cl.DeleteDeploymentsWithContext(ctx, deploymentID); err != nil {
+		return err
+	}
+
+	waiter := cl.executionWaiter()
+	interval := waiter.firstInterval()
+	for {
+		_, err := cl.GetDeploymentWithContext(ctx, deploymentID)
+		if rest.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := sleepWithContext(ctx, interval); err != nil {
+			return err
+		}
+		interval = waiter.nextInterval(interval)
+	}
+}
+
+// Install - upload blueprint with same name as deployment, create deployment
+// and run install workflow
+func (cl *Client) Install(blueprintPath, deploymentID string, inputs map[string]interface{}) (*WaitResult, error) {
+	return cl.InstallWithContext(context.Background(), blueprintPath, deploymentID, inputs)
+}
+
+// InstallWithContext - upload blueprint, create deployment and run install workflow,
+// canceled with context. Blueprint and deployment are removed if install
+// workflow was not started.
+func (cl *Client) InstallWithContext(ctx context.Context, blueprintPath, deploymentID string, inputs map[string]interface{}) (*WaitResult, error) {
+	blueprintID := deploymentID
+
+	cl.reportStep(StepUploadBlueprint, "upload %s as %s", blueprintPath, blueprintID)
+	if _, err := cl.UploadBlueprintWithContext(ctx, blueprintID, blueprintPath); err != nil {
+		return nil, &LifecycleError{Step: StepUploadBlueprint, Err: err}
+	}
+
+	var depl DeploymentPost
+	depl.BlueprintID = blueprintID
+	depl.Inputs = inputs
+	if depl.Inputs == nil {
+		depl.Inputs = map[string]interface{}{}
+	}
+
+	cl.reportStep(StepCreateDeployment, "create deployment %s", deploymentID)
+	if _, err := cl.CreateDeploymentsWithContext(ctx, deploymentID, depl); err != nil {
+		cl.cleanupInstall(ctx, blueprintID, "")
+		return nil, &LifecycleError{Step: StepCreateDeployment, Err: err}
+	}
+
+	cl.reportStep(StepCreateDeployment, "wait for deployment environment")
+	if err := cl.WaitBeforeRunExecutionWithContext(ctx, deploymentID); err != nil {
+		cl.cleanupInstall(ctx, blueprintID, deploymentID)
+		return nil, &LifecycleError{Step: StepCreateDeployment, Err: err}
+	}
+
+	// deployment has resources after install started, keep it for uninstall
+	return cl.runWorkflow(ctx, StepInstall, deploymentID, "install", nil)
+}
+
+// Uninstall - run uninstall workflow, delete deployment and blueprint if
+// deleteBlueprint is set
+func (cl *Client) Uninstall(deploymentID string, deleteBlueprint bool) error {
+	return cl.UninstallWithContext(context.Background(), deploymentID, deleteBlueprint)
+}
+
+// UninstallWithContext - run uninstall workflow, delete deployment and blueprint,
+// canceled with context. Deployment is not deleted if uninstall is failed.
+func (cl *Client) UninstallWithContext(ctx context.Context, deploymentID string, deleteBlueprint bool) error {
+	deployment, err := cl.GetDeploymentWithContext(ctx, deploymentID)
+	if err != nil {
+		return &LifecycleError{Step: StepUninstall, Err: err}
+	}
+
+	if _, err := cl.runWorkflow(ctx, StepUninstall, deploymentID, "uninstall", nil); err != nil {
+		return err
+	}
+
+	cl.reportStep(StepDeleteDeployment, "delete deployment %s", deploymentID)
+	if err := cl.deleteDeploymentAndWait(ctx, deploymentID); err != nil {
+		return &LifecycleError{Step: StepDeleteDeployment, Err: err}
+	}
+
+	if !deleteBlueprint {
+		return nil
+	}
+
+	cl.reportStep(StepDeleteBlueprint, "delete blueprint %s", deployment.BlueprintID)
+	if _, err := cl.DeleteBlueprintsWithContext(ctx, deployment.BlueprintID); err != nil {
+		return &LifecycleError{Step: StepDeleteBlueprint, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"errors"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const environmentFailedResponce = `{
+	"items": [{
+		"id": "e1",
+		"workflow_id": "create_deployment_environment",
+		"status": "failed",
+		"error": "plugin is not installed"
+	}],
+	"metadata": {"pagination": {"total": 1, "offset": 0, "size": 1}}
+}`
+
+// installClient - fake client which fails install on selected step and
+// records sequence of delete calls
+type installClient struct {
+	tests.FakeClient
+	createError  error
+	deleteErrors map[string]error
+	deleted      []string
+}
+
+func (cl *installClient) GetWithContext(ctx context.Context, url, acceptedContentType string) ([]byte, error) {
+	if strings.HasPrefix(url, "executions?") {
+		cl.GetResponse = []byte(environmentFailedResponce)
+		cl.GetError = nil
+	} else {
+		// deployment is already removed by delete call
+		cl.GetResponse = nil
+		cl.GetError = &rest.APIError{Status: 404, Code: "not_found_error"}
+	}
+	return cl.FakeClient.GetWithContext(ctx, url, acceptedContentType)
+}
+
+func (cl *installClient) PutWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	cl.PutError = cl.createError
+	return cl.FakeClient.PutWithContext(ctx, url, providedContentType, data)
+}
+
+func (cl *installClient) DeleteWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	cl.deleted = append(cl.deleted, url)
+	cl.DeleteResponse = []byte(`{}`)
+	cl.DeleteError = cl.deleteErrors[url]
+	return cl.FakeClient.DeleteWithContext(ctx, url, providedContentType, data)
+}
+
+// installFailed - run install with fake connection, check failed step and
+// return sequence of deleted objects
+func installFailed(t *testing.T, conn *installClient) []string {
+	dir, err := ioutil.TempDir("", "lifecycle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	blueprintPath := filepath.Join(dir, "blueprint.yaml")
+	if err := ioutil.WriteFile(blueprintPath, []byte("tosca_definitions_version: cloudify_dsl_1_3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	conn.PutResponse = []byte(`{"id": "deployment"}`)
+	cl := ClientFromConnection(conn)
+	cl.SetExecutionWaiter(&ExecutionWaiter{PollInterval: time.Millisecond})
+
+	_, err = cl.Install(blueprintPath, "deployment", nil)
+	lifecycleErr, ok := err.(*LifecycleError)
+	if !ok {
+		t.Fatalf("Recheck error type: %v", err)
+	}
+	tests.AssertEqual(t, lifecycleErr.Step, StepCreateDeployment,
+		"Recheck failed step: %s", lifecycleErr.Step)
+	return conn.deleted
+}
+
+// TestInstallCreateDeploymentFailed - check that only blueprint is removed
+// if deployment is not created
+func TestInstallCreateDeploymentFailed(t *testing.T) {
+	conn := installClient{createError: errors.New("Deployment already exists")}
+
+	deleted := installFailed(t, &conn)
+	tests.AssertEqual(t, strings.Join(deleted, ","), "blueprints/deployment",
+		"Recheck deleted objects: %v", deleted)
+}
+
+// TestInstallEnvironmentFailed - check that deployment is removed before
+// blueprint if deployment environment is not created
+func TestInstallEnvironmentFailed(t *testing.T) {
+	var conn installClient
+
+	deleted := installFailed(t, &conn)
+	tests.AssertEqual(t, strings.Join(deleted, ","),
+		"deployments/deployment,blueprints/deployment",
+		"Recheck deleted objects: %v", deleted)
+}
+
+// TestInstallDeleteDeploymentFailed - check that blueprint is kept if
+// deployment can't be removed
+func TestInstallDeleteDeploymentFailed(t *testing.T) {
+	conn := installClient{deleteErrors: map[string]error{
+		"deployments/deployment": &rest.APIError{Status: 400, Code: "dependent_exists_error"},
+	}}
+
+	deleted := installFailed(t, &conn)
+	tests.AssertEqual(t, strings.Join(deleted, ","), "deployments/deployment",
+		"Recheck deleted objects: %v", deleted)
+}
+
+// TestUninstallFailed - check that deployment is kept after failed uninstall
+func TestUninstallFailed(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(`{"id": "deployment", "blueprint_id": "blueprint"}`)
+	conn.PostResponse = []byte(executionFailedResponce)
+	cl := ClientFromConnection(&conn)
+
+	var steps []string
+	cl.SetLifecycleHandler(func(step, message string) {
+		steps = append(steps, step)
+	})
+
+	err := cl.Uninstall("deployment", true)
+	lifecycleErr, ok := err.(*LifecycleError)
+	if !ok {
+		t.Errorf("Recheck error type: %v", err)
+		return
+	}
+	tests.AssertEqual(t, lifecycleErr.Step, StepUninstall,
+		"Recheck failed step: %s", lifecycleErr.Step)
+	tests.AssertEqual(t, lifecycleErr.Result.State, WaitFailed,
+		"Recheck execution state: %s", lifecycleErr.Result.State)
+	tests.AssertEqual(t, conn.DeleteURL, "",
+		"Deployment must be kept: %s", conn.DeleteURL)
+	tests.AssertEqual(t, len(steps), 1, "Recheck reported steps: %v", steps)
+}
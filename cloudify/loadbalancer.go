@@ -16,9 +16,18 @@ limitations under the License.
 
 package cloudify
 
+import (
+	"context"
+)
+
 // GetLoadBalancerInstances - return loadbalancer by name/namespace/cluster
 func (cl *Client) GetLoadBalancerInstances(params map[string]string, clusterName, namespace, name, nodeType string) (*NodeInstances, error) {
-	nodeInstancesList, err := cl.GetAliveNodeInstancesWithType(params, nodeType)
+	return cl.GetLoadBalancerInstancesWithContext(context.Background(), params, clusterName, namespace, name, nodeType)
+}
+
+// GetLoadBalancerInstancesWithContext - return loadbalancer by name/namespace/cluster, canceled with context
+func (cl *Client) GetLoadBalancerInstancesWithContext(ctx context.Context, params map[string]string, clusterName, namespace, name, nodeType string) (*NodeInstances, error) {
+	nodeInstancesList, err := cl.GetAliveNodeInstancesWithTypeWithContext(ctx, params, nodeType)
 	if err != nil {
 		return nil, err
 	}
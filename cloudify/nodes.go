@@ -17,6 +17,7 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	"encoding/json"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
@@ -35,21 +36,21 @@ type NodePlugin struct {
 // Node - information about cloudify node
 type Node struct {
 	rest.ObjectIDWithTenant
-	Operations               map[string]interface{} `json:"operations,omitempty"`
-	Relationships            []interface{}          `json:"relationships,omitempty"`
-	DeployNumberOfInstances  int                    `json:"deploy_number_of_instances"`
-	TypeHierarchy            []string               `json:"type_hierarchy,omitempty"`
-	BlueprintID              string                 `json:"blueprint_id,omitempty"`
-	NumberOfInstances        int                    `json:"number_of_instances"`
-	DeploymentID             string                 `json:"deployment_id,omitempty"`
-	Properties               map[string]interface{} `json:"properties,omitempty"`
-	PlannedNumberOfInstances int                    `json:"planned_number_of_instances"`
-	Plugins                  []NodePlugin           `json:"plugins,omitempty"`
-	MaxNumberOfInstances     int                    `json:"max_number_of_instances"`
-	HostID                   string                 `json:"host_id,omitempty"`
-	MinNumberOfInstances     int                    `json:"min_number_of_instances"`
-	Type                     string                 `json:"type,omitempty"`
-	PluginsToInstall         []interface{}          `json:"plugins_to_install,omitempty"`
+	Operations               map[string]NodeOperation `json:"operations,omitempty"`
+	Relationships            []NodeRelationship       `json:"relationships,omitempty"`
+	DeployNumberOfInstances  int                      `json:"deploy_number_of_instances"`
+	TypeHierarchy            []string                 `json:"type_hierarchy,omitempty"`
+	BlueprintID              string                   `json:"blueprint_id,omitempty"`
+	NumberOfInstances        int                      `json:"number_of_instances"`
+	DeploymentID             string                   `json:"deployment_id,omitempty"`
+	Properties               map[string]interface{}   `json:"properties,omitempty"`
+	PlannedNumberOfInstances int                      `json:"planned_number_of_instances"`
+	Plugins                  []NodePlugin             `json:"plugins,omitempty"`
+	MaxNumberOfInstances     int                      `json:"max_number_of_instances"`
+	HostID                   string                   `json:"host_id,omitempty"`
+	MinNumberOfInstances     int                      `json:"min_number_of_instances"`
+	Type                     string                   `json:"type,omitempty"`
+	PluginsToInstall         []NodePlugin             `json:"plugins_to_install,omitempty"`
 }
 
 // GetJSONProperties - properties related to node
@@ -82,14 +83,58 @@ func (nl *Nodes) GetNodeNamesWithType(nodeType string) []string {
 
 // GetNodes - return nodes filtered by params
 func (cl *Client) GetNodes(params map[string]string) (*Nodes, error) {
+	return cl.GetNodesWithContext(context.Background(), params)
+}
+
+// GetNodesWithContext - return nodes filtered by params, canceled with context
+func (cl *Client) GetNodesWithContext(ctx context.Context, params map[string]string) (*Nodes, error) {
 	var nodes Nodes
 
 	values := cl.stringMapToURLValue(params)
 
-	err := cl.Get("nodes?"+values.Encode(), &nodes)
+	err := cl.GetWithContext(ctx, "nodes?"+values.Encode(), &nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodes, nil
+}
+
+// IterateNodes - call handler for each node filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateNodes(ctx context.Context, params map[string]string, handler func(Node) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		nodes, err := cl.GetNodesWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range nodes.Items {
+			if !handler(item) {
+				return nodes.Metadata.Pagination, len(nodes.Items), false, nil
+			}
+		}
+		return nodes.Metadata.Pagination, len(nodes.Items), true, nil
+	})
+}
+
+// GetAllNodes - return nodes from all pages filtered by params
+func (cl *Client) GetAllNodes(params map[string]string) (*Nodes, error) {
+	return cl.GetAllNodesWithContext(context.Background(), params)
+}
+
+// GetAllNodesWithContext - return nodes from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllNodesWithContext(ctx context.Context, params map[string]string) (*Nodes, error) {
+	var nodes Nodes
+
+	err := cl.IterateNodes(ctx, params, func(item Node) bool {
+		nodes.Items = append(nodes.Items, item)
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	nodes.Metadata = allItemsMetadata(len(nodes.Items))
 	return &nodes, nil
 }
@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"fmt"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	"strconv"
+)
+
+// DefaultPageSize - count of items requested by one call in iterators
+const DefaultPageSize = 100
+
+// pageGetter - request one page by params, return pagination from response,
+// count of items on page and false if iteration must be stopped
+type pageGetter func(ctx context.Context, params map[string]string) (rest.Pagination, int, bool, error)
+
+// iteratePages - request pages one by one until all items are returned,
+// start from "_offset" and use "_size" from params as page size
+func iteratePages(ctx context.Context, params map[string]string, getPage pageGetter) error {
+	pageParams := map[string]string{}
+	for key, value := range params {
+		pageParams[key] = value
+	}
+
+	var offset uint
+	if value, ok := params["_offset"]; ok {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Wrong offset: %s", value)
+		}
+		offset = uint(parsed)
+	}
+
+	var size uint = DefaultPageSize
+	if value, ok := params["_size"]; ok {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || parsed == 0 {
+			return fmt.Errorf("Wrong page size: %s", value)
+		}
+		size = uint(parsed)
+	}
+
+	for {
+		pageParams["_offset"] = fmt.Sprintf("%d", offset)
+		pageParams["_size"] = fmt.Sprintf("%d", size)
+
+		pagination, count, next, err := getPage(ctx, pageParams)
+		if err != nil {
+			return err
+		}
+		if !next || count == 0 {
+			return nil
+		}
+
+		offset += uint(count)
+		if offset >= pagination.Total {
+			return nil
+		}
+	}
+}
+
+// allItemsMetadata - metadata for list with all items
+func allItemsMetadata(count int) rest.Metadata {
+	var metadata rest.Metadata
+	metadata.Pagination.Total = uint(count)
+	metadata.Pagination.Size = uint(count)
+	metadata.Pagination.Offset = 0
+	return metadata
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"testing"
+)
+
+// TestIteratePages - check that pages requested until total is reached
+func TestIteratePages(t *testing.T) {
+	var offsets []string
+	err := iteratePages(context.Background(), map[string]string{"_size": "2"},
+		func(ctx context.Context, params map[string]string) (rest.Pagination, int, bool, error) {
+			offsets = append(offsets, params["_offset"])
+			tests.AssertEqual(t, params["_size"], "2",
+				"Recheck page size: %s", params["_size"])
+			var pagination rest.Pagination
+			pagination.Total = 5
+			if params["_offset"] == "4" {
+				return pagination, 1, true, nil
+			}
+			return pagination, 2, true, nil
+		})
+	if err != nil {
+		t.Errorf("Recheck iteration error: %v", err)
+	}
+	tests.AssertEqual(t, len(offsets), 3, "Recheck pages count: %v", offsets)
+	tests.AssertEqual(t, offsets[2], "4", "Recheck last offset: %v", offsets)
+
+	offsets = []string{}
+	err = iteratePages(context.Background(), map[string]string{},
+		func(ctx context.Context, params map[string]string) (rest.Pagination, int, bool, error) {
+			offsets = append(offsets, params["_offset"])
+			var pagination rest.Pagination
+			pagination.Total = 500
+			return pagination, 100, false, nil
+		})
+	if err != nil {
+		t.Errorf("Recheck iteration error: %v", err)
+	}
+	tests.AssertEqual(t, len(offsets), 1, "Recheck early stop: %v", offsets)
+
+	err = iteratePages(context.Background(), map[string]string{"_size": "0"},
+		func(ctx context.Context, params map[string]string) (rest.Pagination, int, bool, error) {
+			t.Error("Page requested with wrong size")
+			return rest.Pagination{}, 0, false, nil
+		})
+	if err == nil {
+		t.Error("Recheck page size validation")
+	}
+}
+
+// TestGetAllExecutions - check that all pages are merged to one list
+func TestGetAllExecutions(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(executionsStartedResponce)
+	cl := ClientFromConnection(&conn)
+
+	executions, err := cl.GetAllExecutions(map[string]string{})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.GetURL, "executions?_offset=0&_size=100",
+		"Recheck url for executions: %s", conn.GetURL)
+	tests.AssertEqual(t, len(executions.Items), 1,
+		"Recheck items count: %d", len(executions.Items))
+	tests.AssertEqual(t, executions.Metadata.Pagination.Total, uint(1),
+		"Recheck total: %d", executions.Metadata.Pagination.Total)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// PlanInput - blueprint input definition
+type PlanInput struct {
+	Type        string      `json:"type,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Description string      `json:"description,omitempty"`
+	// default key is set in blueprint, value can be null
+	defaultSet bool
+}
+
+// UnmarshalJSON - decode input and remember that default key is set
+func (input *PlanInput) UnmarshalJSON(data []byte) error {
+	// alias without custom unmarshal
+	type planInput PlanInput
+
+	var decoded planInput
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*input = PlanInput(decoded)
+	_, input.defaultSet = fields["default"]
+	return nil
+}
+
+// HasDefault - input can be skipped on deployment create,
+// explicit null default is default value too
+func (input *PlanInput) HasDefault() bool {
+	return input.defaultSet || input.Default != nil
+}
+
+// PlanOutput - blueprint output definition
+type PlanOutput struct {
+	Description string      `json:"description,omitempty"`
+	Value       interface{} `json:"value"`
+}
+
+// NodeOperation - operation mapped to plugin task
+type NodeOperation struct {
+	Operation             string                 `json:"operation"`
+	Plugin                string                 `json:"plugin"`
+	Executor              string                 `json:"executor,omitempty"`
+	Inputs                map[string]interface{} `json:"inputs,omitempty"`
+	MaxRetries            int                    `json:"max_retries,omitempty"`
+	RetryInterval         float64                `json:"retry_interval,omitempty"`
+	HasIntrinsicFunctions bool                   `json:"has_intrinsic_functions,omitempty"`
+}
+
+// NodeRelationship - relationship from node to target node
+type NodeRelationship struct {
+	Type             string                   `json:"type"`
+	TargetID         string                   `json:"target_id"`
+	TypeHierarchy    []string                 `json:"type_hierarchy,omitempty"`
+	Properties       map[string]interface{}   `json:"properties,omitempty"`
+	SourceOperations map[string]NodeOperation `json:"source_operations,omitempty"`
+	TargetOperations map[string]NodeOperation `json:"target_operations,omitempty"`
+}
+
+// NodeCapabilities - node capabilities, for now only scaling. Properties
+// can contain intrinsic functions like get_input
+type NodeCapabilities struct {
+	Scalable struct {
+		Properties map[string]interface{} `json:"properties"`
+	} `json:"scalable"`
+}
+
+// PlanNode - node template from blueprint plan
+type PlanNode struct {
+	ID               string                   `json:"id"`
+	Name             string                   `json:"name"`
+	Type             string                   `json:"type"`
+	TypeHierarchy    []string                 `json:"type_hierarchy,omitempty"`
+	Properties       map[string]interface{}   `json:"properties,omitempty"`
+	Operations       map[string]NodeOperation `json:"operations,omitempty"`
+	Relationships    []NodeRelationship       `json:"relationships,omitempty"`
+	Plugins          []NodePlugin             `json:"plugins,omitempty"`
+	PluginsToInstall []NodePlugin             `json:"plugins_to_install,omitempty"`
+	HostID           string                   `json:"host_id,omitempty"`
+	Capabilities     NodeCapabilities         `json:"capabilities"`
+}
+
+// PlanWorkflow - workflow definition from blueprint plan
+type PlanWorkflow struct {
+	Operation  string                 `json:"operation"`
+	Plugin     string                 `json:"plugin"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// PlanRelationshipType - relationship type definition from blueprint plan
+type PlanRelationshipType struct {
+	Name             string                 `json:"name"`
+	DerivedFrom      string                 `json:"derived_from,omitempty"`
+	TypeHierarchy    []string               `json:"type_hierarchy,omitempty"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+	SourceInterfaces map[string]interface{} `json:"source_interfaces,omitempty"`
+	TargetInterfaces map[string]interface{} `json:"target_interfaces,omitempty"`
+}
+
+// PlanPolicy - policy applied to groups
+type PlanPolicy struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Targets    []string               `json:"targets,omitempty"`
+}
+
+// PolicyType - policy type definition
+type PolicyType struct {
+	Source     string                 `json:"source"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+// PolicyTrigger - policy trigger definition
+type PolicyTrigger struct {
+	Source     string                 `json:"source"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// GroupPolicyTrigger - trigger used in group policy
+type GroupPolicyTrigger struct {
+	Type       string                 `json:"type"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// GroupPolicy - policy attached to node group
+type GroupPolicy struct {
+	Type       string                        `json:"type"`
+	Properties map[string]interface{}        `json:"properties,omitempty"`
+	Triggers   map[string]GroupPolicyTrigger `json:"triggers,omitempty"`
+}
+
+// PlanScalingGroup - scaling group definition from blueprint plan,
+// properties can contain intrinsic functions like get_input
+type PlanScalingGroup struct {
+	Properties map[string]interface{} `json:"properties"`
+	Members    []string               `json:"members"`
+}
+
+// BlueprintPlan - parsed blueprint returned by manager
+type BlueprintPlan struct {
+	Description                string                          `json:"description,omitempty"`
+	Inputs                     map[string]PlanInput            `json:"inputs"`
+	Outputs                    map[string]PlanOutput           `json:"outputs"`
+	Nodes                      []PlanNode                      `json:"nodes"`
+	Relationships              map[string]PlanRelationshipType `json:"relationships"`
+	Workflows                  map[string]PlanWorkflow         `json:"workflows"`
+	Policies                   map[string]PlanPolicy           `json:"policies"`
+	PolicyTypes                map[string]PolicyType           `json:"policy_types"`
+	PolicyTriggers             map[string]PolicyTrigger        `json:"policy_triggers"`
+	Groups                     map[string]NodeGroup            `json:"groups"`
+	ScalingGroups              map[string]PlanScalingGroup     `json:"scaling_groups"`
+	DeploymentPluginsToInstall []NodePlugin                    `json:"deployment_plugins_to_install,omitempty"`
+	WorkflowPluginsToInstall   []NodePlugin                    `json:"workflow_plugins_to_install,omitempty"`
+}
+
+// GetNode - return node template by name or nil
+func (plan *BlueprintPlan) GetNode(nodeID string) *PlanNode {
+	for pos := range plan.Nodes {
+		if plan.Nodes[pos].ID == nodeID {
+			return &plan.Nodes[pos]
+		}
+	}
+	return nil
+}
+
+// RequiredInputs - names of inputs without default value
+func (plan *BlueprintPlan) RequiredInputs() []string {
+	required := []string{}
+	for name, input := range plan.Inputs {
+		if !input.HasDefault() {
+			required = append(required, name)
+		}
+	}
+	sort.Strings(required)
+	return required
+}
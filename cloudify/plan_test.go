@@ -0,0 +1,192 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"testing"
+)
+
+const blueprintResponce = `{
+	"id": "blueprint",
+	"main_file_name": "blueprint.yaml",
+	"plan": {
+		"inputs": {
+			"ip": {"type": "string", "description": "Host ip"},
+			"port": {"type": "integer", "default": 8080},
+			"zone": {"type": "string", "default": null}
+		},
+		"outputs": {
+			"endpoint": {"value": {"get_attribute": ["vm", "ip"]}}
+		},
+		"nodes": [{
+			"id": "vm",
+			"name": "vm",
+			"type": "cloudify.nodes.Compute",
+			"type_hierarchy": ["cloudify.nodes.Root", "cloudify.nodes.Compute"],
+			"operations": {
+				"cloudify.interfaces.lifecycle.create": {
+					"operation": "vm.create",
+					"plugin": "vm_plugin",
+					"executor": "central_deployment_agent",
+					"max_retries": -1,
+					"retry_interval": 30
+				}
+			},
+			"capabilities": {
+				"scalable": {"properties": {"min_instances": 0, "max_instances": -1, "default_instances": 1}}
+			}
+		}, {
+			"id": "app",
+			"name": "app",
+			"type": "cloudify.nodes.ApplicationModule",
+			"host_id": "vm",
+			"relationships": [{
+				"type": "cloudify.relationships.contained_in",
+				"target_id": "vm",
+				"type_hierarchy": ["cloudify.relationships.depends_on", "cloudify.relationships.contained_in"],
+				"source_operations": {
+					"cloudify.interfaces.relationship_lifecycle.preconfigure": {
+						"operation": "app.configure",
+						"plugin": "app_plugin"
+					}
+				}
+			}]
+		}],
+		"workflows": {
+			"install": {"operation": "cloudify.plugins.workflows.install", "plugin": "default_workflows"}
+		},
+		"groups": {
+			"autoheal": {
+				"members": ["vm"],
+				"policies": {
+					"heal": {"type": "host_failure", "triggers": {"heal": {"type": "execute_workflow"}}}
+				}
+			}
+		}
+	}
+}`
+
+// TestGetBlueprint - check unmarshal for blueprint plan
+func TestGetBlueprint(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(blueprintResponce)
+	cl := ClientFromConnection(&conn)
+
+	blueprint, err := cl.GetBlueprint("blueprint")
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.GetURL, "blueprints/blueprint",
+		"Recheck url for blueprint: %s", conn.GetURL)
+
+	plan := blueprint.Plan
+	required := plan.RequiredInputs()
+	tests.AssertEqual(t, len(required), 1, "Recheck required inputs: %v", required)
+	tests.AssertEqual(t, required[0], "ip", "Recheck required inputs: %v", required)
+
+	vm := plan.GetNode("vm")
+	if vm == nil {
+		t.Error("Recheck nodes unmarshal")
+		return
+	}
+	create := vm.Operations["cloudify.interfaces.lifecycle.create"]
+	tests.AssertEqual(t, create.RetryInterval, float64(30),
+		"Recheck operation unmarshal: %+v", create)
+	tests.AssertEqual(t, vm.Capabilities.Scalable.Properties["default_instances"], float64(1),
+		"Recheck capabilities unmarshal: %+v", vm.Capabilities)
+
+	app := plan.GetNode("app")
+	tests.AssertEqual(t, app.Relationships[0].TargetID, "vm",
+		"Recheck relationships unmarshal: %+v", app.Relationships)
+	preconfigure := app.Relationships[0].SourceOperations["cloudify.interfaces.relationship_lifecycle.preconfigure"]
+	tests.AssertEqual(t, preconfigure.Plugin, "app_plugin",
+		"Recheck relationship operations unmarshal: %+v", preconfigure)
+
+	tests.AssertEqual(t, plan.Workflows["install"].Plugin, "default_workflows",
+		"Recheck workflows unmarshal: %+v", plan.Workflows)
+	tests.AssertEqual(t, plan.Groups["autoheal"].Policies["heal"].Triggers["heal"].Type, "execute_workflow",
+		"Recheck groups unmarshal: %+v", plan.Groups)
+}
+
+// TestNodesFractionalRetryInterval - check nodes with float retry interval
+func TestNodesFractionalRetryInterval(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(`{"items": [{
+		"id": "vm",
+		"operations": {
+			"cloudify.interfaces.lifecycle.start": {
+				"operation": "vm.start",
+				"plugin": "vm_plugin",
+				"max_retries": 10,
+				"retry_interval": 0.5
+			}
+		}
+	}], "metadata": {"pagination": {"total": 1, "offset": 0, "size": 1}}}`)
+	cl := ClientFromConnection(&conn)
+
+	nodes, err := cl.GetNodes(map[string]string{})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	start := nodes.Items[0].Operations["cloudify.interfaces.lifecycle.start"]
+	tests.AssertEqual(t, start.RetryInterval, 0.5,
+		"Recheck operation unmarshal: %+v", start)
+	tests.AssertEqual(t, start.MaxRetries, 10,
+		"Recheck operation unmarshal: %+v", start)
+}
+
+// TestBlueprintsScalingFunctions - check list of blueprints with intrinsic
+// functions in scaling properties
+func TestBlueprintsScalingFunctions(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(`{"items": [{
+		"id": "blueprint",
+		"plan": {
+			"nodes": [{
+				"id": "vm",
+				"capabilities": {
+					"scalable": {"properties": {"default_instances": {"get_input": "count"}}}
+				}
+			}],
+			"scaling_groups": {
+				"vms": {
+					"members": ["vm"],
+					"properties": {"max_instances": {"get_input": "max_count"}}
+				}
+			}
+		}
+	}], "metadata": {"pagination": {"total": 1, "offset": 0, "size": 1}}}`)
+	cl := ClientFromConnection(&conn)
+
+	blueprints, err := cl.GetBlueprints(map[string]string{})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	plan := blueprints.Items[0].Plan
+	tests.AssertEqual(t, plan.ScalingGroups["vms"].Members[0], "vm",
+		"Recheck scaling groups unmarshal: %+v", plan.ScalingGroups)
+	maxInstances, ok := plan.ScalingGroups["vms"].Properties["max_instances"].(map[string]interface{})
+	if !ok || maxInstances["get_input"] != "max_count" {
+		t.Errorf("Recheck scaling group properties: %+v", plan.ScalingGroups["vms"].Properties)
+	}
+	tests.AssertEqual(t, len(plan.Nodes[0].Capabilities.Scalable.Properties), 1,
+		"Recheck capabilities unmarshal: %+v", plan.Nodes[0].Capabilities)
+}
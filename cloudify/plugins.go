@@ -17,6 +17,8 @@ limitations under the License.
 package cloudify
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 	"os"
@@ -60,11 +62,16 @@ type Plugins struct {
 
 // GetPlugins - return list plugins on manger filtered by params
 func (cl *Client) GetPlugins(params map[string]string) (*Plugins, error) {
+	return cl.GetPluginsWithContext(context.Background(), params)
+}
+
+// GetPluginsWithContext - return list plugins on manger filtered by params, canceled with context
+func (cl *Client) GetPluginsWithContext(ctx context.Context, params map[string]string) (*Plugins, error) {
 	var plugins Plugins
 
 	values := cl.stringMapToURLValue(params)
 
-	err := cl.Get("plugins?"+values.Encode(), &plugins)
+	err := cl.GetWithContext(ctx, "plugins?"+values.Encode(), &plugins)
 	if err != nil {
 		return nil, err
 	}
@@ -72,11 +79,72 @@ func (cl *Client) GetPlugins(params map[string]string) (*Plugins, error) {
 	return &plugins, nil
 }
 
+// IteratePlugins - call handler for each plugin filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IteratePlugins(ctx context.Context, params map[string]string, handler func(Plugin) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		plugins, err := cl.GetPluginsWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range plugins.Items {
+			if !handler(item) {
+				return plugins.Metadata.Pagination, len(plugins.Items), false, nil
+			}
+		}
+		return plugins.Metadata.Pagination, len(plugins.Items), true, nil
+	})
+}
+
+// GetAllPlugins - return plugins from all pages filtered by params
+func (cl *Client) GetAllPlugins(params map[string]string) (*Plugins, error) {
+	return cl.GetAllPluginsWithContext(context.Background(), params)
+}
+
+// GetAllPluginsWithContext - return plugins from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllPluginsWithContext(ctx context.Context, params map[string]string) (*Plugins, error) {
+	var plugins Plugins
+
+	err := cl.IteratePlugins(ctx, params, func(item Plugin) bool {
+		plugins.Items = append(plugins.Items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plugins.Metadata = allItemsMetadata(len(plugins.Items))
+	return &plugins, nil
+}
+
+// GetPlugin - return plugin by id
+func (cl *Client) GetPlugin(pluginID string) (*Plugin, error) {
+	return cl.GetPluginWithContext(context.Background(), pluginID)
+}
+
+// GetPluginWithContext - return plugin by id, canceled with context
+func (cl *Client) GetPluginWithContext(ctx context.Context, pluginID string) (*Plugin, error) {
+	var plugin PluginGet
+
+	err := cl.GetWithContext(ctx, "plugins/"+pluginID, &plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	return &plugin.Plugin, nil
+}
+
 //DeletePlugins - delete plugin by id
 func (cl *Client) DeletePlugins(pluginID string, params CallWithForce) (*PluginGet, error) {
+	return cl.DeletePluginsWithContext(context.Background(), pluginID, params)
+}
+
+//DeletePluginsWithContext - delete plugin by id, canceled with context
+func (cl *Client) DeletePluginsWithContext(ctx context.Context, pluginID string, params CallWithForce) (*PluginGet, error) {
 	var plugin PluginGet
 
-	err := cl.Delete("plugins/"+pluginID, params, &plugin)
+	err := cl.DeleteWithContext(ctx, "plugins/"+pluginID, params, &plugin)
 	if err != nil {
 		return nil, err
 	}
@@ -84,13 +152,49 @@ func (cl *Client) DeletePlugins(pluginID string, params CallWithForce) (*PluginG
 	return &plugin, nil
 }
 
-//UploadPlugin - upload plugin with path to plugin in filesystem
+//UploadPlugin - upload plugin with path to plugin in filesystem, plugin yaml
+// is checked against wagon metadata before upload
 func (cl *Client) UploadPlugin(params map[string]string, pluginPath, yamlPath string) (*PluginGet, error) {
+	return cl.UploadPluginWithContext(context.Background(), params, pluginPath, yamlPath)
+}
+
+//UploadPluginWithContext - upload plugin with path to plugin in filesystem,
+// plugin yaml is checked against wagon metadata before upload, canceled with context
+func (cl *Client) UploadPluginWithContext(ctx context.Context, params map[string]string, pluginPath, yamlPath string) (*PluginGet, error) {
+	wagon, err := InspectWagon(pluginPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := wagon.CheckPluginYaml(yamlPath); err != nil {
+		return nil, err
+	}
+
 	var plugin PluginGet
 
 	values := cl.stringMapToURLValue(params)
 
-	err := cl.PostZip("plugins?"+values.Encode(), []string{pluginPath, yamlPath}, &plugin)
+	err = cl.PostZipWithContext(ctx, "plugins?"+values.Encode(), []string{pluginPath, yamlPath}, &plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	return &plugin, nil
+}
+
+//UploadPluginURL - upload plugin from wagon url, wagon is downloaded by manager
+func (cl *Client) UploadPluginURL(params map[string]string, pluginURL string) (*PluginGet, error) {
+	return cl.UploadPluginURLWithContext(context.Background(), params, pluginURL)
+}
+
+//UploadPluginURLWithContext - upload plugin from wagon url, wagon is downloaded
+// by manager, canceled with context
+func (cl *Client) UploadPluginURLWithContext(ctx context.Context, params map[string]string, pluginURL string) (*PluginGet, error) {
+	var plugin PluginGet
+
+	values := cl.stringMapToURLValue(params)
+	values.Set("plugin_archive_url", pluginURL)
+
+	err := cl.PostBinaryStreamWithContext(ctx, "plugins?"+values.Encode(), bytes.NewReader([]byte{}), 0, &plugin)
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +204,11 @@ func (cl *Client) UploadPlugin(params map[string]string, pluginPath, yamlPath st
 
 //DownloadPlugins - download plugin by id
 func (cl *Client) DownloadPlugins(pluginID string) (string, error) {
+	return cl.DownloadPluginsWithContext(context.Background(), pluginID)
+}
+
+//DownloadPluginsWithContext - download plugin by id, canceled with context
+func (cl *Client) DownloadPluginsWithContext(ctx context.Context, pluginID string) (string, error) {
 	fileName := pluginID + ".wgn"
 
 	_, errFile := os.Stat(fileName)
@@ -107,7 +216,7 @@ func (cl *Client) DownloadPlugins(pluginID string) (string, error) {
 		return "", fmt.Errorf("file `%s` is exist", fileName)
 	}
 
-	err := cl.GetBinary("plugins/"+pluginID+"/archive", fileName)
+	err := cl.GetBinaryWithContext(ctx, "plugins/"+pluginID+"/archive", fileName)
 	if err != nil {
 		return "", err
 	}
@@ -162,3 +162,35 @@ func TestDeletePlugins(t *testing.T) {
 	tests.AssertEqual(t, plugin.ID, "0227b9c2-6180-4fad-b448-02da74f33155",
 		"Recheck unmarshal for 'id' field in plugin '%s'", plugin.ID)
 }
+
+// TestGetPlugin - check GetPlugin
+func TestGetPlugin(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(pluginsDeleteResponce)
+	cl := ClientFromConnection(&conn)
+	plugin, err := cl.GetPlugin("0227b9c2-6180-4fad-b448-02da74f33155")
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.GetURL, "plugins/0227b9c2-6180-4fad-b448-02da74f33155",
+		"Recheck url for plugin: %s", conn.GetURL)
+	tests.AssertEqual(t, plugin.PackageName, "cloudify-vsphere-plugin",
+		"Recheck unmarshal for 'package_name' field in plugin '%s'", plugin.PackageName)
+}
+
+// TestUploadPluginURL - check that wagon url is sent as parameter
+func TestUploadPluginURL(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PostResponse = []byte(pluginsDeleteResponce)
+	cl := ClientFromConnection(&conn)
+	_, err := cl.UploadPluginURL(map[string]string{"visibility": "global"},
+		"http://example.com/plugin.wgn")
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PostURL,
+		"plugins?plugin_archive_url=http%3A%2F%2Fexample.com%2Fplugin.wgn&visibility=global",
+		"Recheck url for upload: %s", conn.PostURL)
+}
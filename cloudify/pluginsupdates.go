@@ -0,0 +1,198 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	"log"
+)
+
+// Plugins update states returned by manager
+const (
+	PluginsUpdateUpdating          = "updating"
+	PluginsUpdateExecutingWorkflow = "executing_workflow"
+	PluginsUpdateFinalizing        = "finalizing"
+	PluginsUpdateSuccessful        = "successful"
+	PluginsUpdateFailed            = "failed"
+	PluginsUpdateNoChangesRequired = "no_changes_required"
+)
+
+// PluginsUpdatePost - information for start plugins update
+type PluginsUpdatePost struct {
+	// start update even if other update for blueprint is not finished
+	Force bool `json:"force"`
+}
+
+// PluginsUpdate - information about update of deployments created from
+// blueprint to latest versions of used plugins
+type PluginsUpdate struct {
+	rest.ObjectIDWithTenant
+	State               string   `json:"state"`
+	BlueprintID         string   `json:"blueprint_id"`
+	TempBlueprintID     string   `json:"temp_blueprint_id"`
+	ExecutionID         string   `json:"execution_id"`
+	DeploymentsToUpdate []string `json:"deployments_to_update"`
+	Forced              bool     `json:"forced"`
+	CreatedAt           string   `json:"created_at"`
+}
+
+// PluginsUpdateGet - response from manager about selected plugins update
+type PluginsUpdateGet struct {
+	rest.BaseMessage
+	PluginsUpdate
+}
+
+// PluginsUpdates - response from manager with plugins updates list
+type PluginsUpdates struct {
+	rest.BaseMessage
+	Metadata rest.Metadata   `json:"metadata"`
+	Items    []PluginsUpdate `json:"items"`
+}
+
+// PluginsUpdateWaitResult - plugins update state after wait
+type PluginsUpdateWaitResult struct {
+	State  WaitState
+	Update PluginsUpdate
+}
+
+// IsFinishedPluginsUpdateState - plugins update with such state will never change state again
+func IsFinishedPluginsUpdateState(state string) bool {
+	return state == PluginsUpdateSuccessful || state == PluginsUpdateFailed ||
+		state == PluginsUpdateNoChangesRequired
+}
+
+// GetPluginsUpdates - return plugins updates filtered by params
+func (cl *Client) GetPluginsUpdates(params map[string]string) (*PluginsUpdates, error) {
+	return cl.GetPluginsUpdatesWithContext(context.Background(), params)
+}
+
+// GetPluginsUpdatesWithContext - return plugins updates filtered by params,
+// canceled with context
+func (cl *Client) GetPluginsUpdatesWithContext(ctx context.Context, params map[string]string) (*PluginsUpdates, error) {
+	var updates PluginsUpdates
+
+	values := cl.stringMapToURLValue(params)
+
+	err := cl.GetWithContext(ctx, "plugins-updates?"+values.Encode(), &updates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updates, nil
+}
+
+// GetPluginsUpdate - return plugins update by id
+func (cl *Client) GetPluginsUpdate(updateID string) (*PluginsUpdate, error) {
+	return cl.GetPluginsUpdateWithContext(context.Background(), updateID)
+}
+
+// GetPluginsUpdateWithContext - return plugins update by id, canceled with context
+func (cl *Client) GetPluginsUpdateWithContext(ctx context.Context, updateID string) (*PluginsUpdate, error) {
+	var update PluginsUpdateGet
+
+	err := cl.GetWithContext(ctx, "plugins-updates/"+updateID, &update)
+	if err != nil {
+		return nil, err
+	}
+
+	return &update.PluginsUpdate, nil
+}
+
+// UpdatePlugins - start update of deployments created from blueprint to
+// latest uploaded versions of plugins used by blueprint
+func (cl *Client) UpdatePlugins(blueprintID string, update PluginsUpdatePost) (*PluginsUpdate, error) {
+	return cl.UpdatePluginsWithContext(context.Background(), blueprintID, update)
+}
+
+// UpdatePluginsWithContext - start update of deployments created from blueprint
+// to latest uploaded versions of plugins, canceled with context
+func (cl *Client) UpdatePluginsWithContext(ctx context.Context, blueprintID string, update PluginsUpdatePost) (*PluginsUpdate, error) {
+	var started PluginsUpdateGet
+
+	err := cl.PostWithContext(ctx, "plugins-updates/"+blueprintID+"/update/initiate", update, &started)
+	if err != nil {
+		return nil, err
+	}
+
+	return &started.PluginsUpdate, nil
+}
+
+// WaitPluginsUpdate - wait while plugins update will be finished
+func (cl *Client) WaitPluginsUpdate(updateID string) (*PluginsUpdateWaitResult, error) {
+	return cl.WaitPluginsUpdateWithContext(context.Background(), updateID)
+}
+
+// WaitPluginsUpdateWithContext - wait while plugins update will be finished,
+// canceled with context. Uses same intervals, timeout and event handler as
+// execution waiter.
+func (cl *Client) WaitPluginsUpdateWithContext(ctx context.Context, updateID string) (*PluginsUpdateWaitResult, error) {
+	waiter := cl.executionWaiter()
+	waitCtx := ctx
+	if waiter.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, waiter.Timeout)
+		defer cancel()
+	}
+
+	update, err := cl.GetPluginsUpdateWithContext(waitCtx, updateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventsOffset int
+	interval := waiter.firstInterval()
+	for !IsFinishedPluginsUpdateState(update.State) {
+		if cl.restCl().GetDebug() {
+			log.Printf("Check state for %v, last state: %v", update.ID, update.State)
+		}
+
+		err := sleepWithContext(waitCtx, interval)
+		if err == nil {
+			var current *PluginsUpdate
+			current, err = cl.GetPluginsUpdateWithContext(waitCtx, updateID)
+			if err == nil {
+				update = current
+				if update.ExecutionID != "" {
+					eventsOffset, err = cl.reportExecutionEvents(waitCtx, waiter, update.ExecutionID, eventsOffset)
+				}
+			}
+		}
+		if err != nil {
+			// our own timeout, parent context is still alive
+			if waitCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				return &PluginsUpdateWaitResult{State: WaitTimedOut, Update: *update}, nil
+			}
+			return nil, err
+		}
+
+		interval = waiter.nextInterval(interval)
+	}
+
+	if update.ExecutionID != "" {
+		_, err := cl.reportFinalEvents(waitCtx, waiter, update.ExecutionID, eventsOffset)
+		// update is finished, our own timeout only stops events report
+		if err != nil && (waitCtx.Err() != context.DeadlineExceeded || ctx.Err() != nil) {
+			return nil, err
+		}
+	}
+
+	if update.State == PluginsUpdateFailed {
+		return &PluginsUpdateWaitResult{State: WaitFailed, Update: *update}, nil
+	}
+	return &PluginsUpdateWaitResult{State: WaitTerminated, Update: *update}, nil
+}
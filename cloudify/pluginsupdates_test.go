@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"testing"
+)
+
+const pluginsUpdateResponce = `{
+	"id": "9e5d1a46-2fc9-4cbb-9c2e-7d1e2c0b5a47",
+	"state": "successful",
+	"blueprint_id": "blueprint",
+	"temp_blueprint_id": "blueprint-temp",
+	"execution_id": "",
+	"deployments_to_update": ["deployment"],
+	"forced": false
+}`
+
+// TestUpdatePlugins - check request for start plugins update and wait result
+func TestUpdatePlugins(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PostResponse = []byte(pluginsUpdateResponce)
+	conn.GetResponse = []byte(pluginsUpdateResponce)
+	cl := ClientFromConnection(&conn)
+
+	update, err := cl.UpdatePlugins("blueprint", PluginsUpdatePost{Force: true})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PostURL, "plugins-updates/blueprint/update/initiate",
+		"Recheck url for update: %s", conn.PostURL)
+	tests.AssertEqual(t, string(conn.PostData), `{"force":true}`,
+		"Recheck update request: %s", string(conn.PostData))
+
+	result, err := cl.WaitPluginsUpdate(update.ID)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.GetURL, "plugins-updates/9e5d1a46-2fc9-4cbb-9c2e-7d1e2c0b5a47",
+		"Recheck url for update: %s", conn.GetURL)
+	tests.AssertEqual(t, result.State, WaitTerminated,
+		"Recheck wait state: %s", result.State)
+	tests.AssertEqual(t, len(result.Update.DeploymentsToUpdate), 1,
+		"Recheck updated deployments: %v", result.Update.DeploymentsToUpdate)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"io"
+)
+
+// ProgressHandler - called while binary content is sent to or received from
+// manager, total is -1 if size is unknown. Last call at end of content has
+// done equal to total.
+type ProgressHandler func(done, total int64)
+
+// SetProgressHandler - use handler for report progress of binary uploads and downloads
+func (cl *Client) SetProgressHandler(handler ProgressHandler) {
+	cl.progressHandler = handler
+}
+
+// progressReader - reader which reports count of already read bytes
+type progressReader struct {
+	reader  io.Reader
+	done    int64
+	total   int64
+	handler ProgressHandler
+	// end of content is already reported
+	finished bool
+}
+
+// Read - read from original reader and report progress
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.done += int64(n)
+	if r.finished {
+		return n, err
+	}
+	if r.done == r.total || err == io.EOF {
+		// size is known only at the end for unknown size
+		r.finished = true
+		r.handler(r.done, r.done)
+	} else if n > 0 {
+		r.handler(r.done, r.total)
+	}
+	return n, err
+}
+
+// progressReadSeeker - progress reader for seekable stream, keep stream
+// seekable for allow retry of requests
+type progressReadSeeker struct {
+	progressReader
+}
+
+// Seek - seek in original stream and count progress from new position
+func (r *progressReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.reader.(io.Seeker).Seek(offset, whence)
+	if err == nil {
+		r.done = 0
+		r.finished = false
+	}
+	return pos, err
+}
+
+// withProgress - wrap reader for report progress if handler is set
+func (cl *Client) withProgress(reader io.Reader, total int64) io.Reader {
+	if cl.progressHandler == nil {
+		return reader
+	}
+	progress := progressReader{reader: reader, total: total, handler: cl.progressHandler}
+	if _, ok := reader.(io.Seeker); ok {
+		return &progressReadSeeker{progress}
+	}
+	return &progress
+}
@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// Authenticator - add credentials to requests for manager
+type Authenticator interface {
+	// Authenticate - add credentials to request
+	Authenticate(ctx context.Context, client *HTTPClient, req *http.Request) error
+	// Reset - drop cached credentials after unauthorized response,
+	// return true if request can be repeated with new credentials
+	Reset() bool
+	// String - description without secrets, used in debug output
+	String() string
+}
+
+// BasicAuth - send user and password with each request
+type BasicAuth struct {
+	User     string
+	Password string
+}
+
+// header - value for Authorization header
+func (a *BasicAuth) header() string {
+	authString := a.User + ":" + a.Password
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(authString))
+}
+
+// Authenticate - add basic auth header
+func (a *BasicAuth) Authenticate(ctx context.Context, client *HTTPClient, req *http.Request) error {
+	req.Header.Set("Authorization", a.header())
+	return nil
+}
+
+// Reset - nothing cached, repeat will not help
+func (a *BasicAuth) Reset() bool {
+	return false
+}
+
+// String - user name without password
+func (a *BasicAuth) String() string {
+	return a.User + ":***"
+}
+
+// TokenHeader - header used by manager for token authentication
+const TokenHeader = "Authentication-Token"
+
+// TokenAuth - send API token with each request
+type TokenAuth struct {
+	Token string
+}
+
+// Authenticate - add token header
+func (a *TokenAuth) Authenticate(ctx context.Context, client *HTTPClient, req *http.Request) error {
+	req.Header.Set(TokenHeader, a.Token)
+	return nil
+}
+
+// Reset - token is static, repeat will not help
+func (a *TokenAuth) Reset() bool {
+	return false
+}
+
+// String - token is hidden
+func (a *TokenAuth) String() string {
+	return "token:***"
+}
+
+// tokenResponse - response from manager for token request
+type tokenResponse struct {
+	Role  string `json:"role"`
+	Value string `json:"value"`
+}
+
+// SessionTokenAuth - get token by user and password from manager,
+// cache it and get new one after unauthorized response
+type SessionTokenAuth struct {
+	User     string
+	Password string
+
+	mutex sync.Mutex
+	token string
+}
+
+// Authenticate - add cached token header, get token if not cached
+func (a *SessionTokenAuth) Authenticate(ctx context.Context, client *HTTPClient, req *http.Request) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.token == "" {
+		token, err := a.fetchToken(ctx, client)
+		if err != nil {
+			return err
+		}
+		a.token = token
+	}
+	req.Header.Set(TokenHeader, a.token)
+	return nil
+}
+
+// fetchToken - get new token from manager by basic auth
+func (a *SessionTokenAuth) fetchToken(ctx context.Context, client *HTTPClient) (string, error) {
+	client.debugLogf("Get token for %v\n", a.String())
+
+	req, err := http.NewRequest("GET", client.restURL+"tokens", nil)
+	if err != nil {
+		return "", err
+	}
+	basic := BasicAuth{User: a.User, Password: a.Password}
+	req.Header.Set("Authorization", basic.header())
+	if len(client.tenant) > 0 {
+		req.Header.Set("Tenant", client.tenant)
+	}
+
+	resp, err := client.doRequest(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", newAPIError("GET", client.restURL+"tokens", resp, body)
+	}
+
+	// response is not logged, it contains token
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("Can't get token, status: %s", resp.Status)
+	}
+	if token.Value == "" {
+		return "", fmt.Errorf("Can't get token, status: %s", resp.Status)
+	}
+	return token.Value, nil
+}
+
+// Reset - drop cached token
+func (a *SessionTokenAuth) Reset() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.token = ""
+	return true
+}
+
+// String - user name without password and token
+func (a *SessionTokenAuth) String() string {
+	return a.User + ":*** (session token)"
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// newTokenTestServer - manager mock, generate new token on each tokens call
+// and accept only last one
+func newTokenTestServer(tokenRequests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", JSONContentType)
+		currentToken := fmt.Sprintf("token-%d", *tokenRequests)
+		if r.URL.Path == "/api/"+APIVersion+"/tokens" {
+			user, password, ok := r.BasicAuth()
+			if !ok || user != "admin" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message": "wrong user", "error_code": "unauthorized_error"}`))
+				return
+			}
+			*tokenRequests++
+			w.Write([]byte(fmt.Sprintf(`{"role": "admin", "value": "token-%d"}`, *tokenRequests)))
+			return
+		}
+		if r.Header.Get(TokenHeader) != currentToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message": "wrong token", "error_code": "unauthorized_error"}`))
+			return
+		}
+		w.Write([]byte(`{"status": "running"}`))
+	}))
+}
+
+func TestSessionTokenAuth(t *testing.T) {
+	var tokenRequests int
+	ts := newTokenTestServer(&tokenRequests)
+	defer ts.Close()
+
+	auth := &SessionTokenAuth{User: "admin", Password: "secret"}
+	cl := NewAuthClient(ts.URL, "default_tenant", auth, TLSConfig{})
+
+	for i := 0; i < 2; i++ {
+		if _, err := cl.Get("status", JSONContentType); err != nil {
+			t.Errorf("Request with token must be accepted: %s", err.Error())
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("Token must be cached, requested %d times", tokenRequests)
+	}
+
+	// manager has forgotten token
+	tokenRequests++
+	if _, err := cl.Get("status", JSONContentType); err != nil {
+		t.Errorf("Token must be refreshed: %s", err.Error())
+	}
+	if tokenRequests != 3 {
+		t.Errorf("Token must be requested again, requested %d times", tokenRequests)
+	}
+}
+
+func TestTokenAuth(t *testing.T) {
+	var tokenRequests int
+	ts := newTokenTestServer(&tokenRequests)
+	defer ts.Close()
+
+	cl := NewAuthClient(ts.URL, "default_tenant", &TokenAuth{Token: "token-0"}, TLSConfig{})
+	if _, err := cl.Get("status", JSONContentType); err != nil {
+		t.Errorf("Request with token must be accepted: %s", err.Error())
+	}
+	if tokenRequests != 0 {
+		t.Errorf("Static token must be used, requested %d times", tokenRequests)
+	}
+}
+
+func TestDebugRedactCredentials(t *testing.T) {
+	var tokenRequests int
+	ts := newTokenTestServer(&tokenRequests)
+	defer ts.Close()
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	cl := NewAuthClient(ts.URL, "default_tenant",
+		&SessionTokenAuth{User: "admin", Password: "secret"}, TLSConfig{})
+	cl.SetDebug(true)
+	if _, err := cl.Get("status", JSONContentType); err != nil {
+		t.Errorf("Request with token must be accepted: %s", err.Error())
+	}
+
+	cl = NewClient(ts.URL, "admin", "secret", "default_tenant")
+	cl.SetDebug(true)
+	cl.Get("status", JSONContentType)
+
+	if strings.Contains(output.String(), "secret") || strings.Contains(output.String(), "token-1") {
+		t.Errorf("Credentials must be hidden in debug output: %s", output.String())
+	}
+	if !strings.Contains(output.String(), "admin:***") {
+		t.Errorf("User must be visible in debug output: %s", output.String())
+	}
+}
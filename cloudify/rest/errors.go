@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError - error response from manager
+type APIError struct {
+	// Status - http status code
+	Status int
+	// Code - manager error code like "not_found_error", can be empty
+	// for errors from proxy
+	Code      string
+	Message   string
+	Traceback string
+	// Method/URL - failed request
+	Method string
+	URL    string
+}
+
+// ErrorCode - manager error code, support reuse as MessageInterface
+func (e *APIError) ErrorCode() string {
+	return e.Code
+}
+
+// TraceBack - traceback from manager
+func (e *APIError) TraceBack() string {
+	return e.Traceback
+}
+
+// Error - description of error
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.Status)
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("%s %s: %d %s (%s)", e.Method, e.URL, e.Status, message, e.Code)
+	}
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.Status, message)
+}
+
+// newAPIError - create error by failed response and response body
+func newAPIError(method, url string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Status: resp.StatusCode,
+		Method: method,
+		URL:    url,
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, JSONContentType) {
+		var message CommonMessage
+		if json.Unmarshal(body, &message) == nil {
+			apiErr.Code = message.ClErrorCode
+			apiErr.Message = message.ClMessage
+			apiErr.Traceback = message.ClServerTraceback
+		}
+	}
+	return apiErr
+}
+
+// AsAPIError - return manager error if err is such error
+func AsAPIError(err error) (*APIError, bool) {
+	apiErr, ok := err.(*APIError)
+	return apiErr, ok && apiErr != nil
+}
+
+// hasError - check error status or manager error code
+func hasError(err error, status int, code string) bool {
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	return apiErr.Status == status || apiErr.Code == code
+}
+
+// IsNotFound - object does not exist on manager
+func IsNotFound(err error) bool {
+	return hasError(err, http.StatusNotFound, "not_found_error")
+}
+
+// IsConflict - object already exists or is used by other operation
+func IsConflict(err error) bool {
+	return hasError(err, http.StatusConflict, "conflict_error")
+}
+
+// IsUnauthorized - credentials rejected by manager
+func IsUnauthorized(err error) bool {
+	return hasError(err, http.StatusUnauthorized, "unauthorized_error")
+}
+
+// IsForbidden - user has no permissions for operation
+func IsForbidden(err error) bool {
+	return hasError(err, http.StatusForbidden, "forbidden_error")
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newErrorTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/" + APIVersion + "/deployments/unknown":
+			w.Header().Set("Content-Type", JSONContentType)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{
+				"message": "Requested Deployment with ID ` + "`unknown`" + ` was not found",
+				"error_code": "not_found_error",
+				"server_traceback": "Traceback (most recent call last): ..."
+			}`))
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+		}
+	}))
+}
+
+func TestAPIErrorFromJSON(t *testing.T) {
+	ts := newErrorTestServer()
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+	_, err := cl.Get("deployments/unknown", JSONContentType)
+
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("Error must be APIError: %v", err)
+	}
+	if apiErr.Status != http.StatusNotFound || apiErr.Code != "not_found_error" {
+		t.Errorf("Wrong status/code: %d/%s", apiErr.Status, apiErr.Code)
+	}
+	if apiErr.Method != "GET" || apiErr.URL != ts.URL+"/api/"+APIVersion+"/deployments/unknown" {
+		t.Errorf("Wrong request: %s %s", apiErr.Method, apiErr.URL)
+	}
+	if apiErr.TraceBack() == "" {
+		t.Error("Traceback must be saved")
+	}
+	if !IsNotFound(err) || IsConflict(err) || IsUnauthorized(err) {
+		t.Errorf("Wrong error kind: %v", err)
+	}
+}
+
+func TestAPIErrorFromHTML(t *testing.T) {
+	ts := newErrorTestServer()
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+	_, err := cl.Delete("deployments/other", JSONContentType, nil)
+
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		t.Fatalf("Error must be APIError: %v", err)
+	}
+	if apiErr.Status != http.StatusBadGateway || apiErr.Code != "" {
+		t.Errorf("Wrong status/code: %d/%s", apiErr.Status, apiErr.Code)
+	}
+	if IsNotFound(err) {
+		t.Error("Bad gateway is not not found")
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	if IsNotFound(fmt.Errorf("not found")) {
+		t.Error("Only APIError can be checked")
+	}
+	if !IsConflict(&APIError{Code: "conflict_error"}) {
+		t.Error("Conflict must be checked by error code")
+	}
+	if !IsUnauthorized(&APIError{Status: http.StatusUnauthorized}) {
+		t.Error("Unauthorized must be checked by status")
+	}
+}
+
+func ExampleAPIError() {
+	err := &APIError{
+		Status:  http.StatusNotFound,
+		Code:    "not_found_error",
+		Message: "Requested Deployment with ID `unknown` was not found",
+		Method:  "GET",
+		URL:     "http://localhost/api/v3.1/deployments/unknown",
+	}
+	fmt.Println(err.Error())
+	// Output: GET http://localhost/api/v3.1/deployments/unknown: 404 Requested Deployment with ID `unknown` was not found (not_found_error)
+}
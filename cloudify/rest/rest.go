@@ -21,7 +21,7 @@ package rest
 
 import (
 	"bytes"
-	"encoding/base64"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -37,11 +37,15 @@ const DataContentType = "application/octet-stream"
 
 // HTTPClient - Credentials for cloudify
 type HTTPClient struct {
-	restURL  string
-	user     string
-	password string
-	tenant   string
-	debug    bool
+	restURL string
+	auth    Authenticator
+	tenant  string
+	debug   bool
+	client  *http.Client
+	// policy for repeat requests, nil for disable
+	retryPolicy RetryPolicy
+	// error in client configuration, will be returned on any request
+	clientErr error
 }
 
 func (r *HTTPClient) debugLogf(format string, v ...interface{}) {
@@ -51,17 +55,17 @@ func (r *HTTPClient) debugLogf(format string, v ...interface{}) {
 }
 
 // getRequest - create new request by params
-func (r *HTTPClient) getRequest(url, method string, body io.Reader) (*http.Request, error) {
-	r.debugLogf("Use: %v %v:%v@%v#%s\n", method, r.user, r.password, r.restURL+url, r.tenant)
+func (r *HTTPClient) getRequest(ctx context.Context, url, method string, body io.Reader) (*http.Request, error) {
+	r.debugLogf("Use: %v %v@%v#%s\n", method, r.auth.String(), r.restURL+url, r.tenant)
 
-	var authString string
-	authString = r.user + ":" + r.password
 	req, err := http.NewRequest(method, r.restURL+url, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(authString)))
+	if err := r.auth.Authenticate(ctx, r, req); err != nil {
+		return nil, err
+	}
 	if len(r.tenant) > 0 {
 		req.Header.Add("Tenant", r.tenant)
 	}
@@ -69,28 +73,167 @@ func (r *HTTPClient) getRequest(url, method string, body io.Reader) (*http.Reque
 	return req, nil
 }
 
-// Get - http(s) get request
-func (r *HTTPClient) Get(url, acceptedContentType string) ([]byte, error) {
-	req, err := r.getRequest(url, "GET", nil)
-	if err != nil {
-		return []byte{}, err
+// doRequest - send request with cancellation by context
+func (r *HTTPClient) doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if r.clientErr != nil {
+		return nil, r.clientErr
 	}
+	client := r.client
+	if client == nil {
+		client = &http.Client{}
+	}
+	return client.Do(req.WithContext(ctx))
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return []byte{}, err
+// requestBody - content of request, stream content can be sent again
+// only if stream is seekable
+type requestBody struct {
+	data   []byte
+	stream io.Reader
+	// stream size, -1 if size is unknown
+	size int64
+	// stream position before first attempt
+	start int64
+	used  bool
+}
+
+// newDataBody - request content from bytes, nil for request without content
+func newDataBody(data []byte) *requestBody {
+	if data == nil {
+		return nil
 	}
+	return &requestBody{data: data}
+}
 
-	defer resp.Body.Close()
+// newStreamBody - request content from stream with size, -1 for unknown size
+func newStreamBody(stream io.Reader, size int64) *requestBody {
+	return &requestBody{stream: stream, size: size}
+}
 
-	contentType := resp.Header.Get("Content-Type")
+// canRepeat - check that content can be sent again
+func (b *requestBody) canRepeat() bool {
+	if b == nil || b.stream == nil || !b.used {
+		return true
+	}
+	_, ok := b.stream.(io.Seeker)
+	return ok
+}
 
-	if len(contentType) < len(acceptedContentType) || contentType[:len(acceptedContentType)] != acceptedContentType {
-		return []byte{}, fmt.Errorf("Wrong content type: %+v", contentType)
+// reader - return reader for next attempt and content size
+func (b *requestBody) reader() (io.Reader, int64, error) {
+	if b == nil {
+		return nil, 0, nil
+	}
+	if b.stream == nil {
+		return bytes.NewReader(b.data), int64(len(b.data)), nil
+	}
+	if seeker, ok := b.stream.(io.Seeker); ok {
+		var err error
+		if b.used {
+			_, err = seeker.Seek(b.start, io.SeekStart)
+		} else {
+			b.start, err = seeker.Seek(0, io.SeekCurrent)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	} else if b.used {
+		return nil, 0, fmt.Errorf("Stream content can't be sent again")
+	}
+	b.used = true
+	return b.stream, b.size, nil
+}
+
+// sendRequest - send request, repeat it by retry policy on transient failures
+func (r *HTTPClient) sendRequest(ctx context.Context, method, url, providedContentType string, body *requestBody) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := r.sendAuthRequest(ctx, method, url, providedContentType, body)
+		if r.retryPolicy == nil || ctx.Err() != nil || !canRetry(ctx, method) || !body.canRepeat() {
+			return resp, err
+		}
+
+		delay, retry := r.retryPolicy.NextRetry(attempt, method, resp, err)
+		if !retry {
+			return resp, err
+		}
+
+		if err != nil {
+			r.debugLogf("Attempt %d for %v %v failed: %v, retry after %v\n",
+				attempt, method, url, err, delay)
+		} else {
+			r.debugLogf("Attempt %d for %v %v failed: %v, retry after %v\n",
+				attempt, method, url, resp.Status, delay)
+			resp.Body.Close()
+		}
+
+		if err := sleepWithContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sendAuthRequest - create and send request, repeat it once with new credentials
+// if manager has rejected current
+func (r *HTTPClient) sendAuthRequest(ctx context.Context, method, url, providedContentType string, body *requestBody) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		input, size, err := body.reader()
+		if err != nil {
+			return nil, err
+		}
+		req, err := r.getRequest(ctx, url, method, input)
+		if err != nil {
+			return nil, err
+		}
+		if input != nil {
+			req.Header.Set("Content-Type", providedContentType)
+			if size >= 0 {
+				req.ContentLength = size
+			}
+		}
+
+		resp, err := r.doRequest(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 || !body.canRepeat() || !r.auth.Reset() {
+			return resp, nil
+		}
+		r.debugLogf("Unauthorized, retry with new credentials\n")
+		resp.Body.Close()
+	}
+}
+
+// checkStatus - return manager error for failed response
+func (r *HTTPClient) checkStatus(method, url string, resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
 	}
 
 	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	r.debugLogf("Error response %v: %s\n", resp.Status, string(body))
+
+	return newAPIError(method, r.restURL+url, resp, body)
+}
+
+// Get - http(s) get request
+func (r *HTTPClient) Get(url, acceptedContentType string) ([]byte, error) {
+	return r.GetWithContext(context.Background(), url, acceptedContentType)
+}
+
+// GetWithContext - http(s) get request, canceled with context
+func (r *HTTPClient) GetWithContext(ctx context.Context, url, acceptedContentType string) ([]byte, error) {
+	stream, _, err := r.GetStreamWithContext(ctx, url, acceptedContentType)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	defer stream.Close()
+
+	body, err := ioutil.ReadAll(stream)
 	if err != nil {
 		return []byte{}, err
 	}
@@ -104,28 +247,57 @@ func (r *HTTPClient) Get(url, acceptedContentType string) ([]byte, error) {
 	return body, nil
 }
 
-// Delete - http(s) delete request
-func (r *HTTPClient) Delete(url, providedContentType string, data []byte) ([]byte, error) {
-	var input io.Reader
-	if len(data) > 0 {
-		input = bytes.NewBuffer(data)
-	}
-	req, err := r.getRequest(url, "DELETE", input)
+// GetStream - http(s) get request, response content is returned as stream
+func (r *HTTPClient) GetStream(url, acceptedContentType string) (io.ReadCloser, int64, error) {
+	return r.GetStreamWithContext(context.Background(), url, acceptedContentType)
+}
+
+// GetStreamWithContext - http(s) get request, response content is returned as
+// stream with size (-1 if size is unknown), canceled with context.
+// Stream must be closed by caller.
+func (r *HTTPClient) GetStreamWithContext(ctx context.Context, url, acceptedContentType string) (io.ReadCloser, int64, error) {
+	resp, err := r.sendRequest(ctx, "GET", url, "", nil)
 	if err != nil {
-		return []byte{}, err
+		return nil, 0, err
+	}
+
+	if err := r.checkStatus("GET", url, resp); err != nil {
+		resp.Body.Close()
+		return nil, 0, err
 	}
-	if input != nil {
-		req.Header.Set("Content-Type", providedContentType)
+
+	contentType := resp.Header.Get("Content-Type")
+
+	if len(contentType) < len(acceptedContentType) || contentType[:len(acceptedContentType)] != acceptedContentType {
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("Wrong content type: %+v", contentType)
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	return resp.Body, resp.ContentLength, nil
+}
+
+// Delete - http(s) delete request
+func (r *HTTPClient) Delete(url, providedContentType string, data []byte) ([]byte, error) {
+	return r.DeleteWithContext(context.Background(), url, providedContentType, data)
+}
+
+// DeleteWithContext - http(s) delete request, canceled with context. Request is
+// not repeated by retry policy without AllowRetry in context.
+func (r *HTTPClient) DeleteWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		data = nil
+	}
+	resp, err := r.sendRequest(ctx, "DELETE", url, providedContentType, newDataBody(data))
 	if err != nil {
 		return []byte{}, err
 	}
 
 	defer resp.Body.Close()
 
+	if err := r.checkStatus("DELETE", url, resp); err != nil {
+		return []byte{}, err
+	}
+
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return []byte{}, err
@@ -142,68 +314,104 @@ func (r *HTTPClient) Delete(url, providedContentType string, data []byte) ([]byt
 	return body, nil
 }
 
-// Post - http(s) post request
-func (r *HTTPClient) Post(url, providedContentType string, data []byte) ([]byte, error) {
-	req, err := r.getRequest(url, "POST", bytes.NewBuffer(data))
+// sendContent - send request with content, return json response
+func (r *HTTPClient) sendContent(ctx context.Context, method, url, providedContentType string, body *requestBody) ([]byte, error) {
+	resp, err := r.sendRequest(ctx, method, url, providedContentType, body)
 	if err != nil {
 		return []byte{}, err
 	}
-	req.Header.Set("Content-Type", providedContentType)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
+	defer resp.Body.Close()
+
+	if err := r.checkStatus(method, url, resp); err != nil {
 		return []byte{}, err
 	}
 
-	defer resp.Body.Close()
-
 	contentType := resp.Header.Get("Content-Type")
 
 	if len(contentType) < len(JSONContentType) || contentType[:len(JSONContentType)] != JSONContentType {
 		return []byte{}, fmt.Errorf("Wrong content type: %+v", contentType)
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
+	respBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return []byte{}, err
 	}
 
-	r.debugLogf("Response %s\n", string(body))
+	r.debugLogf("Response %s\n", string(respBody))
 
-	return body, nil
+	return respBody, nil
+}
+
+// Post - http(s) post request
+func (r *HTTPClient) Post(url, providedContentType string, data []byte) ([]byte, error) {
+	return r.PostWithContext(context.Background(), url, providedContentType, data)
+}
+
+// PostWithContext - http(s) post request, canceled with context. Request is
+// not repeated by retry policy without AllowRetry in context.
+func (r *HTTPClient) PostWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	if data == nil {
+		data = []byte{}
+	}
+	return r.sendContent(ctx, "POST", url, providedContentType, newDataBody(data))
+}
+
+// PostStream - http(s) post request with content from stream
+func (r *HTTPClient) PostStream(url, providedContentType string, input io.Reader, size int64) ([]byte, error) {
+	return r.PostStreamWithContext(context.Background(), url, providedContentType, input, size)
+}
+
+// PostStreamWithContext - http(s) post request with content from stream,
+// size is -1 if size is unknown, canceled with context. Request is repeated
+// only with AllowRetry in context and if stream is seekable.
+func (r *HTTPClient) PostStreamWithContext(ctx context.Context, url, providedContentType string, input io.Reader, size int64) ([]byte, error) {
+	return r.sendContent(ctx, "POST", url, providedContentType, newStreamBody(input, size))
 }
 
 // Put - http(s) put request
 func (r *HTTPClient) Put(url, providedContentType string, data []byte) ([]byte, error) {
-	req, err := r.getRequest(url, "PUT", bytes.NewBuffer(data))
-	if err != nil {
-		return []byte{}, err
-	}
-	req.Header.Set("Content-Type", providedContentType)
+	return r.PutWithContext(context.Background(), url, providedContentType, data)
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return []byte{}, err
+// PutWithContext - http(s) put request, canceled with context. Request is
+// not repeated by retry policy without AllowRetry in context.
+func (r *HTTPClient) PutWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	if data == nil {
+		data = []byte{}
 	}
+	return r.sendContent(ctx, "PUT", url, providedContentType, newDataBody(data))
+}
 
-	defer resp.Body.Close()
+// PutStream - http(s) put request with content from stream
+func (r *HTTPClient) PutStream(url, providedContentType string, input io.Reader, size int64) ([]byte, error) {
+	return r.PutStreamWithContext(context.Background(), url, providedContentType, input, size)
+}
 
-	contentType := resp.Header.Get("Content-Type")
+// PutStreamWithContext - http(s) put request with content from stream,
+// size is -1 if size is unknown, canceled with context. Request is repeated
+// only with AllowRetry in context and if stream is seekable.
+func (r *HTTPClient) PutStreamWithContext(ctx context.Context, url, providedContentType string, input io.Reader, size int64) ([]byte, error) {
+	return r.sendContent(ctx, "PUT", url, providedContentType, newStreamBody(input, size))
+}
 
-	if len(contentType) < len(JSONContentType) || contentType[:len(JSONContentType)] != JSONContentType {
-		return []byte{}, fmt.Errorf("Wrong content type: %+v", contentType)
-	}
+// Patch - http(s) patch request
+func (r *HTTPClient) Patch(url, providedContentType string, data []byte) ([]byte, error) {
+	return r.PatchWithContext(context.Background(), url, providedContentType, data)
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return []byte{}, err
+// PatchWithContext - http(s) patch request, canceled with context. Request is
+// not repeated by retry policy without AllowRetry in context.
+func (r *HTTPClient) PatchWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	if data == nil {
+		data = []byte{}
 	}
+	return r.sendContent(ctx, "PATCH", url, providedContentType, newDataBody(data))
+}
 
-	r.debugLogf("Response %s\n", string(body))
-
-	return body, nil
+// SetRetryPolicy - change policy for repeat requests, nil for disable
+func (r *HTTPClient) SetRetryPolicy(policy RetryPolicy) {
+	r.retryPolicy = policy
 }
 
 // GetDebug - get current debug state
@@ -218,16 +426,33 @@ func (r *HTTPClient) SetDebug(state bool) {
 
 // NewClient - create new http(s) client
 func NewClient(host, user, password, tenant string) ConnectionOperationsInterface {
+	return NewTLSClient(host, user, password, tenant, TLSConfig{})
+}
+
+// NewTLSClient - create new http(s) client with TLS settings,
+// host without scheme will use https if any of TLS settings is set.
+// Errors in TLS settings will be returned on each request.
+func NewTLSClient(host, user, password, tenant string, tlsConfig TLSConfig) ConnectionOperationsInterface {
+	return NewAuthClient(host, tenant, &BasicAuth{User: user, Password: password}, tlsConfig)
+}
+
+// NewAuthClient - create new http(s) client with custom authentication
+func NewAuthClient(host, tenant string, auth Authenticator, tlsConfig TLSConfig) ConnectionOperationsInterface {
 	var restCl HTTPClient
-	if len(host) >= len("http://") && (host[:len("https://")] == "https://" ||
-		host[:len("http://")] == "http://") {
+	if len(host) >= len("http://") && (host[:len("http://")] == "http://" ||
+		(len(host) >= len("https://") && host[:len("https://")] == "https://")) {
 		restCl.restURL = host + "/api/" + APIVersion + "/"
+	} else if !tlsConfig.IsEmpty() {
+		restCl.restURL = "https://" + host + "/api/" + APIVersion + "/"
 	} else {
 		restCl.restURL = "http://" + host + "/api/" + APIVersion + "/"
 	}
-	restCl.user = user
-	restCl.password = password
+	restCl.auth = auth
 	restCl.tenant = tenant
 	restCl.debug = false
+	restCl.client, restCl.clientErr = tlsConfig.newHTTPClient()
+	if restCl.clientErr != nil {
+		restCl.clientErr = fmt.Errorf("Can't configure TLS: %s", restCl.clientErr.Error())
+	}
 	return &restCl
 }
@@ -17,8 +17,13 @@ limitations under the License.
 package rest
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestNewClientDebug(t *testing.T) {
@@ -43,3 +48,78 @@ func ExampleNewClient() {
 	fmt.Printf("Debug: %+v", cl.GetDebug())
 	// Output: Debug: false
 }
+
+func TestGetWithContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := cl.GetWithContext(ctx, "status", JSONContentType)
+	if err == nil {
+		t.Error("Request must be canceled by context.")
+	}
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("Context must be expired, got: %v", ctx.Err())
+	}
+}
+
+func TestGetStream(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", DataContentType)
+		w.Header().Set("Content-Length", "15")
+		w.Write([]byte("archive content"))
+	}))
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+
+	stream, size, err := cl.GetStream("snapshots/backup/archive", DataContentType)
+	if err != nil {
+		t.Errorf("Stream must be returned: %s", err.Error())
+		return
+	}
+	defer stream.Close()
+
+	body, err := ioutil.ReadAll(stream)
+	if err != nil {
+		t.Errorf("Stream must be readable: %s", err.Error())
+	}
+	if string(body) != "archive content" || size != 15 {
+		t.Errorf("Wrong response: %s (%d)", string(body), size)
+	}
+
+	if _, _, err := cl.GetStream("snapshots/backup/archive", JSONContentType); err == nil {
+		t.Error("Content type must be checked")
+	}
+}
+
+func TestPatch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", JSONContentType)
+		w.Write([]byte(fmt.Sprintf(`{"method": "%s", "body": %s}`, r.Method, string(body))))
+	}))
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+
+	body, err := cl.Patch("node-instances/vm", JSONContentType, []byte(`{"version":1}`))
+	if err != nil {
+		t.Errorf("Response must be returned: %s", err.Error())
+		return
+	}
+	if string(body) != `{"method": "PATCH", "body": {"version":1}}` {
+		t.Errorf("Wrong response: %s", string(body))
+	}
+}
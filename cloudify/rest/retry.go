@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy - decide when failed request must be repeated
+type RetryPolicy interface {
+	// NextRetry - delay before next attempt and false if request must not
+	// be repeated, attempt is count of already finished attempts, resp or err
+	// is result of last attempt
+	NextRetry(attempt int, method string, resp *http.Response, err error) (time.Duration, bool)
+}
+
+// BackoffRetryPolicy - exponential backoff with jitter
+type BackoffRetryPolicy struct {
+	// MaxAttempts - full count of attempts, include first one
+	MaxAttempts int
+	// InitialBackoff - delay after first attempt
+	InitialBackoff time.Duration
+	// MaxBackoff - max delay between attempts
+	MaxBackoff time.Duration
+	// Jitter - part of delay (0..1) which will be randomly reduced
+	Jitter float64
+	// StatusCodes - http statuses of response which can be retried
+	StatusCodes []int
+}
+
+// NewBackoffRetryPolicy - retry policy with default delays for manager restart
+func NewBackoffRetryPolicy(maxAttempts int) *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+		Jitter:         0.5,
+		StatusCodes: []int{
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// NextRetry - retry on any connection error and on selected http statuses
+func (p *BackoffRetryPolicy) NextRetry(attempt int, method string, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	if err == nil {
+		if resp == nil {
+			return 0, false
+		}
+		transient := false
+		for _, code := range p.StatusCodes {
+			if resp.StatusCode == code {
+				transient = true
+				break
+			}
+		}
+		if !transient {
+			return 0, false
+		}
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff - delay before next attempt
+func (p *BackoffRetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 && delay > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// retryAllowedKey - context key for enable retry of non idempotent requests
+type retryAllowedKey struct{}
+
+// AllowRetry - return context which enable retry for requests which can
+// change state on manager (POST, PUT, PATCH, DELETE), use only for
+// operations which are safe to repeat
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryAllowedKey{}, true)
+}
+
+// canRetry - check that request with such method can be repeated, PUT and
+// DELETE create and remove objects on manager and are not repeated by default
+func canRetry(ctx context.Context, method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	allowed, _ := ctx.Value(retryAllowedKey{}).(bool)
+	return allowed
+}
+
+// sleepWithContext - wait some time or return error if context is done before
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
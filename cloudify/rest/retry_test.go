@@ -0,0 +1,233 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFlakyTestServer - manager mock, return 503 for first failures requests
+func newFlakyTestServer(failures int, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Header().Set("Content-Type", JSONContentType)
+		if *requests <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message": "restarting"}`))
+			return
+		}
+		w.Write([]byte(`{"status": "running"}`))
+	}))
+}
+
+func newTestRetryPolicy(maxAttempts int) *BackoffRetryPolicy {
+	policy := NewBackoffRetryPolicy(maxAttempts)
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	var requests int
+	ts := newFlakyTestServer(2, &requests)
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+	cl.SetRetryPolicy(newTestRetryPolicy(3))
+
+	body, err := cl.Get("status", JSONContentType)
+	if err != nil {
+		t.Errorf("Request must be retried: %s", err.Error())
+	}
+	if string(body) != `{"status": "running"}` {
+		t.Errorf("Wrong response: %s", string(body))
+	}
+	if requests != 3 {
+		t.Errorf("Request must be sent 3 times, sent %d", requests)
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	var requests int
+	ts := newFlakyTestServer(5, &requests)
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+	cl.SetRetryPolicy(newTestRetryPolicy(2))
+
+	cl.Get("status", JSONContentType)
+	if requests != 2 {
+		t.Errorf("Request must be sent 2 times, sent %d", requests)
+	}
+}
+
+func TestRetryPost(t *testing.T) {
+	var requests int
+	ts := newFlakyTestServer(1, &requests)
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+	cl.SetRetryPolicy(newTestRetryPolicy(3))
+
+	cl.Post("executions", JSONContentType, []byte("{}"))
+	if requests != 1 {
+		t.Errorf("Post must not be retried by default, sent %d", requests)
+	}
+
+	requests = 0
+	_, err := cl.PostWithContext(AllowRetry(context.Background()),
+		"executions", JSONContentType, []byte("{}"))
+	if err != nil {
+		t.Errorf("Post must be retried: %s", err.Error())
+	}
+	if requests != 2 {
+		t.Errorf("Post must be sent 2 times, sent %d", requests)
+	}
+}
+
+func TestRetryPutDelete(t *testing.T) {
+	var requests int
+	ts := newFlakyTestServer(1, &requests)
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+	cl.SetRetryPolicy(newTestRetryPolicy(3))
+
+	// put creates objects, response can be lost after successful create
+	cl.Put("deployments/deployment", JSONContentType, []byte("{}"))
+	if requests != 1 {
+		t.Errorf("Put must not be retried by default, sent %d", requests)
+	}
+
+	requests = 0
+	cl.Delete("deployments/deployment", JSONContentType, nil)
+	if requests != 1 {
+		t.Errorf("Delete must not be retried by default, sent %d", requests)
+	}
+
+	requests = 0
+	_, err := cl.PutWithContext(AllowRetry(context.Background()),
+		"deployments/deployment", JSONContentType, []byte("{}"))
+	if err != nil {
+		t.Errorf("Put must be retried: %s", err.Error())
+	}
+	if requests != 2 {
+		t.Errorf("Put must be sent 2 times, sent %d", requests)
+	}
+}
+
+func TestRetryConnectionError(t *testing.T) {
+	var requests int
+	ts := newFlakyTestServer(0, &requests)
+	url := ts.URL
+	ts.Close()
+
+	cl := NewClient(url, "admin", "password", "default_tenant")
+	cl.SetRetryPolicy(newTestRetryPolicy(3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := cl.GetWithContext(ctx, "status", JSONContentType); err == nil {
+		t.Error("Closed server must be reported")
+	}
+}
+
+func TestBackoffRetryPolicy(t *testing.T) {
+	policy := NewBackoffRetryPolicy(5)
+	policy.Jitter = 0
+
+	expected := []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+	}
+	for attempt, needed := range expected {
+		delay, retry := policy.NextRetry(attempt+1, "GET", nil, context.DeadlineExceeded)
+		if !retry || delay != needed {
+			t.Errorf("Attempt %d: expected %v, got %v/%v", attempt+1, needed, delay, retry)
+		}
+	}
+	if _, retry := policy.NextRetry(5, "GET", nil, context.DeadlineExceeded); retry {
+		t.Error("Attempts must be limited")
+	}
+	resp := &http.Response{StatusCode: http.StatusNotFound}
+	if _, retry := policy.NextRetry(1, "GET", resp, nil); retry {
+		t.Error("Not found must not be retried")
+	}
+}
+
+func TestRetrySeekableStream(t *testing.T) {
+	var requests int
+	var received string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+		w.Header().Set("Content-Type", JSONContentType)
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message": "restarting"}`))
+			return
+		}
+		w.Write([]byte(`{"status": "uploaded"}`))
+	}))
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+	cl.SetRetryPolicy(newTestRetryPolicy(3))
+
+	_, err := cl.PutStreamWithContext(AllowRetry(context.Background()),
+		"snapshots/backup/archive", DataContentType,
+		strings.NewReader("archive content"), int64(len("archive content")))
+	if err != nil {
+		t.Errorf("Request must be retried: %s", err.Error())
+	}
+	if requests != 2 {
+		t.Errorf("Request must be sent 2 times, sent %d", requests)
+	}
+	if received != "archive content" {
+		t.Errorf("Stream must be sent from start, got: %s", received)
+	}
+}
+
+func TestRetryNotSeekableStream(t *testing.T) {
+	var requests int
+	ts := newFlakyTestServer(1, &requests)
+	defer ts.Close()
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+	cl.SetRetryPolicy(newTestRetryPolicy(3))
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.Write([]byte("archive content"))
+		writer.Close()
+	}()
+	_, err := cl.PutStreamWithContext(AllowRetry(context.Background()),
+		"snapshots/backup/archive", DataContentType, reader, -1)
+	if err == nil {
+		t.Error("Stream must not be sent again")
+	}
+	if requests != 1 {
+		t.Errorf("Request must be sent once, sent %d", requests)
+	}
+}
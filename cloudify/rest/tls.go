@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// TLSConfig - settings for https connection to manager
+type TLSConfig struct {
+	// CAFile - path to PEM bundle with trusted certificate authorities
+	CAFile string
+	// CAPEM - PEM encoded trusted certificate authorities
+	CAPEM string
+	// ClientCertFile/ClientKeyFile - client certificate for mutual TLS
+	ClientCertFile string
+	ClientKeyFile  string
+	// ServerName - override server name used for SNI and verification
+	ServerName string
+	// Insecure - skip any verification of manager certificate
+	Insecure bool
+	// Fingerprint - sha256 of manager certificate in hex,
+	// with or without ':' separators
+	Fingerprint string
+}
+
+// IsEmpty - true if no one of TLS settings is set
+func (c TLSConfig) IsEmpty() bool {
+	return c == TLSConfig{}
+}
+
+// normalizeFingerprint - lower case hex without separators
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.Replace(fingerprint, ":", "", -1))
+}
+
+// CertificateFingerprint - sha256 fingerprint of DER encoded certificate
+func CertificateFingerprint(der []byte) string {
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyFingerprint - return function for check that manager certificate is pinned
+func verifyFingerprint(fingerprint string) func([][]byte, [][]*x509.Certificate) error {
+	expected := normalizeFingerprint(fingerprint)
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("Manager has not provided any certificate")
+		}
+		current := CertificateFingerprint(rawCerts[0])
+		if current != expected {
+			return fmt.Errorf("Manager certificate fingerprint %s does not match pinned %s",
+				current, expected)
+		}
+		return nil
+	}
+}
+
+// clientTLSConfig - convert settings to tls config
+func (c TLSConfig) clientTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: c.ServerName,
+	}
+
+	if c.CAFile != "" || c.CAPEM != "" {
+		pool := x509.NewCertPool()
+		if c.CAFile != "" {
+			caData, err := ioutil.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			if !pool.AppendCertsFromPEM(caData) {
+				return nil, fmt.Errorf("No certificates found in %s", c.CAFile)
+			}
+		}
+		if c.CAPEM != "" {
+			if !pool.AppendCertsFromPEM([]byte(c.CAPEM)) {
+				return nil, fmt.Errorf("No certificates found in CA PEM")
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		if c.ClientCertFile == "" || c.ClientKeyFile == "" {
+			return nil, fmt.Errorf("Client certificate and key must be provided together")
+		}
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.Fingerprint != "" {
+		// pinned certificate is checked instead of certificate chain,
+		// so self signed manager certificates are also supported
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = verifyFingerprint(c.Fingerprint)
+	} else if c.Insecure {
+		log.Printf("Warning: manager certificate verification is disabled, " +
+			"connection is insecure.")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return tlsConfig, nil
+}
+
+// newHTTPClient - create http client with transport configured by TLS settings
+func (c TLSConfig) newHTTPClient() (*http.Client, error) {
+	if c.IsEmpty() {
+		return &http.Client{}, nil
+	}
+
+	tlsConfig, err := c.clientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTLSTestServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", JSONContentType)
+		w.Write([]byte(`{"status": "running"}`))
+	}))
+}
+
+func TestTLSClientCAPEM(t *testing.T) {
+	ts := newTLSTestServer()
+	defer ts.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{
+		Type: "CERTIFICATE", Bytes: ts.Certificate().Raw,
+	})
+
+	cl := NewClient(ts.URL, "admin", "password", "default_tenant")
+	if _, err := cl.Get("status", JSONContentType); err == nil {
+		t.Error("Unknown certificate authority must be rejected.")
+	}
+
+	cl = NewTLSClient(ts.URL, "admin", "password", "default_tenant",
+		TLSConfig{CAPEM: string(caPEM)})
+	if _, err := cl.Get("status", JSONContentType); err != nil {
+		t.Errorf("Certificate must be trusted: %s", err.Error())
+	}
+}
+
+func TestTLSClientFingerprint(t *testing.T) {
+	ts := newTLSTestServer()
+	defer ts.Close()
+
+	fingerprint := CertificateFingerprint(ts.Certificate().Raw)
+
+	cl := NewTLSClient(ts.URL, "admin", "password", "default_tenant",
+		TLSConfig{Fingerprint: strings.ToUpper(fingerprint)})
+	if _, err := cl.Get("status", JSONContentType); err != nil {
+		t.Errorf("Pinned certificate must be trusted: %s", err.Error())
+	}
+
+	cl = NewTLSClient(ts.URL, "admin", "password", "default_tenant",
+		TLSConfig{Fingerprint: strings.Repeat("00", 32)})
+	if _, err := cl.Get("status", JSONContentType); err == nil {
+		t.Error("Certificate with other fingerprint must be rejected.")
+	}
+}
+
+func TestTLSClientBrokenConfig(t *testing.T) {
+	cl := NewTLSClient("localhost", "admin", "password", "default_tenant",
+		TLSConfig{ClientCertFile: "client.crt"})
+	_, err := cl.Get("status", JSONContentType)
+	if err == nil || !strings.HasPrefix(err.Error(), "Can't configure TLS") {
+		t.Errorf("Broken TLS config must be reported: %v", err)
+	}
+}
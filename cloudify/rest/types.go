@@ -16,6 +16,11 @@ limitations under the License.
 
 package rest
 
+import (
+	"context"
+	"io"
+)
+
 // APIVersion - currently supported version of Cloudify API
 const APIVersion = "v3.1"
 
@@ -30,7 +35,7 @@ type MessageInterface interface {
 // Note: We need Cl prefix for make fields public and use in Marshal func
 // Check https://blog.golang.org/json-and-go for more info about json marshaling.
 type CommonMessage struct {
-	MessageInterface
+	MessageInterface  `json:"-"`
 	ClMessage         string `json:"message,omitempty"`
 	ClErrorCode       string `json:"error_code,omitempty"`
 	ClServerTraceback string `json:"server_traceback,omitempty"`
@@ -107,6 +112,19 @@ type ConnectionOperationsInterface interface {
 	Delete(url, providedContentType string, data []byte) ([]byte, error)
 	Post(url, providedContentType string, data []byte) ([]byte, error)
 	Put(url, providedContentType string, data []byte) ([]byte, error)
+	Patch(url, providedContentType string, data []byte) ([]byte, error)
+	GetWithContext(ctx context.Context, url, acceptedContentType string) ([]byte, error)
+	DeleteWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error)
+	PostWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error)
+	PutWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error)
+	PatchWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error)
+	GetStream(url, acceptedContentType string) (io.ReadCloser, int64, error)
+	PostStream(url, providedContentType string, input io.Reader, size int64) ([]byte, error)
+	PutStream(url, providedContentType string, input io.Reader, size int64) ([]byte, error)
+	GetStreamWithContext(ctx context.Context, url, acceptedContentType string) (io.ReadCloser, int64, error)
+	PostStreamWithContext(ctx context.Context, url, providedContentType string, input io.Reader, size int64) ([]byte, error)
+	PutStreamWithContext(ctx context.Context, url, providedContentType string, input io.Reader, size int64) ([]byte, error)
 	SetDebug(bool)
 	GetDebug() bool
+	SetRetryPolicy(RetryPolicy)
 }
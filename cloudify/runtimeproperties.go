@@ -0,0 +1,262 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SplitPropertyPath - split path like "networks.0.ip" or "networks[0].ip"
+// to keys: networks, 0, ip. Dot in key is escaped by backslash
+// `cloudify\.kubernetes.url` or key is quoted in brackets
+// `["cloudify.kubernetes"].url` (or with single quotes). Same format is used
+// by cfy-go jsonpath option.
+func SplitPropertyPath(path string) ([]string, error) {
+	keys := []string{}
+	key := []byte{}
+	hasKey := false
+	flush := func() {
+		if hasKey {
+			keys = append(keys, string(key))
+		}
+		key = key[:0]
+		hasKey = false
+	}
+
+	for pos := 0; pos < len(path); pos++ {
+		switch path[pos] {
+		case '\\':
+			pos++
+			if pos >= len(path) {
+				return nil, fmt.Errorf("Property path %s ends with escape", path)
+			}
+			key = append(key, path[pos])
+			hasKey = true
+		case '.':
+			flush()
+		case '[':
+			flush()
+			if pos+1 < len(path) && (path[pos+1] == '"' || path[pos+1] == '\'') {
+				quote := path[pos+1]
+				end := strings.IndexByte(path[pos+2:], quote)
+				if end < 0 {
+					return nil, fmt.Errorf("Property path %s: quote is not closed", path)
+				}
+				end += pos + 2
+				if end+1 >= len(path) || path[end+1] != ']' {
+					return nil, fmt.Errorf("Property path %s: ] expected after quoted key", path)
+				}
+				keys = append(keys, path[pos+2:end])
+				pos = end + 1
+				continue
+			}
+			end := strings.IndexByte(path[pos+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("Property path %s: ] is not found", path)
+			}
+			end += pos + 1
+			if end > pos+1 {
+				keys = append(keys, path[pos+1:end])
+			}
+			pos = end
+		default:
+			key = append(key, path[pos])
+			hasKey = true
+		}
+	}
+	flush()
+	return keys, nil
+}
+
+// joinPropertyPath - join keys to path, dots in keys are escaped
+func joinPropertyPath(keys []string) string {
+	escaped := make([]string, len(keys))
+	for pos, key := range keys {
+		key = strings.Replace(key, "\\", "\\\\", -1)
+		escaped[pos] = strings.Replace(key, ".", "\\.", -1)
+	}
+	return strings.Join(escaped, ".")
+}
+
+// LookupPath - return runtime property value selected by path, keys in path
+// are separated by dot, lists are indexed by number: "networks.0.ip" or
+// "networks[0].ip", keys with dots are escaped or quoted as described in
+// SplitPropertyPath. Error describes first key which is not found.
+func (instance *NodeInstance) LookupPath(path string) (interface{}, error) {
+	keys, err := SplitPropertyPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("Empty property path")
+	}
+
+	var value interface{} = instance.RuntimeProperties
+	for pos, key := range keys {
+		current := joinPropertyPath(keys[:pos+1])
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			item, ok := typed[key]
+			if !ok {
+				return nil, fmt.Errorf("Property %s is not found", current)
+			}
+			value = item
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil {
+				return nil, fmt.Errorf("Property %s: list index expected, got %s", current, key)
+			}
+			if index < 0 || index >= len(typed) {
+				return nil, fmt.Errorf("Property %s: index is out of range, list has %d items", current, len(typed))
+			}
+			value = typed[index]
+		default:
+			return nil, fmt.Errorf("Property %s is not found, parent is not map or list", current)
+		}
+	}
+	return value, nil
+}
+
+// GetPath - return runtime property value selected by path or nil if value
+// does not exist, path format is same as in LookupPath
+func (instance *NodeInstance) GetPath(path string) interface{} {
+	value, err := instance.LookupPath(path)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// GetStringPath - return runtime property selected by path as string,
+// numbers and booleans are converted to string
+func (instance *NodeInstance) GetStringPath(path string) (string, error) {
+	value, err := instance.LookupPath(path)
+	if err != nil {
+		return "", err
+	}
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	case float64:
+		// without exponent for big integer values
+		return strconv.FormatFloat(typed, 'f', -1, 64), nil
+	case bool, json.Number:
+		return fmt.Sprintf("%v", typed), nil
+	}
+	return "", fmt.Errorf("Property %s is %T, string expected", path, value)
+}
+
+// GetIntPath - return runtime property selected by path as int, string
+// values with number are converted to int
+func (instance *NodeInstance) GetIntPath(path string) (int, error) {
+	value, err := instance.LookupPath(path)
+	if err != nil {
+		return 0, err
+	}
+	switch typed := value.(type) {
+	case float64:
+		if typed != math.Trunc(typed) {
+			return 0, fmt.Errorf("Property %s is not integer: %v", path, typed)
+		}
+		return int(typed), nil
+	case int:
+		return typed, nil
+	case json.Number:
+		return strconv.Atoi(typed.String())
+	case string:
+		result, err := strconv.Atoi(strings.TrimSpace(typed))
+		if err != nil {
+			return 0, fmt.Errorf("Property %s is not integer: %s", path, typed)
+		}
+		return result, nil
+	}
+	return 0, fmt.Errorf("Property %s is %T, int expected", path, value)
+}
+
+// GetBoolPath - return runtime property selected by path as bool, string
+// values like "true" or "false" are converted to bool
+func (instance *NodeInstance) GetBoolPath(path string) (bool, error) {
+	value, err := instance.LookupPath(path)
+	if err != nil {
+		return false, err
+	}
+	switch typed := value.(type) {
+	case bool:
+		return typed, nil
+	case string:
+		result, err := strconv.ParseBool(strings.TrimSpace(typed))
+		if err != nil {
+			return false, fmt.Errorf("Property %s is not boolean: %s", path, typed)
+		}
+		return result, nil
+	}
+	return false, fmt.Errorf("Property %s is %T, bool expected", path, value)
+}
+
+// GetStringListPath - return runtime property selected by path as list of
+// strings, all items in list must be strings
+func (instance *NodeInstance) GetStringListPath(path string) ([]string, error) {
+	value, err := instance.LookupPath(path)
+	if err != nil {
+		return nil, err
+	}
+	switch typed := value.(type) {
+	case []string:
+		return typed, nil
+	case []interface{}:
+		result := make([]string, len(typed))
+		for pos, item := range typed {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("Property %s.%d is %T, string expected", path, pos, item)
+			}
+			result[pos] = str
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("Property %s is %T, list expected", path, value)
+}
+
+// GetMapPath - return runtime property selected by path as map
+func (instance *NodeInstance) GetMapPath(path string) (map[string]interface{}, error) {
+	value, err := instance.LookupPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if typed, ok := value.(map[string]interface{}); ok {
+		return typed, nil
+	}
+	return nil, fmt.Errorf("Property %s is %T, map expected", path, value)
+}
+
+// DecodeRuntimeProperties - decode all runtime properties to output struct,
+// fields are matched by json tags
+func (instance *NodeInstance) DecodeRuntimeProperties(output interface{}) error {
+	jsonData, err := json.Marshal(instance.RuntimeProperties)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(jsonData, output); err != nil {
+		return fmt.Errorf("Can't decode runtime properties of %s: %s",
+			instance.ID, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"encoding/json"
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"testing"
+)
+
+const runtimePropertiesInstance = `{
+	"id": "vm_x4d5w2",
+	"runtime_properties": {
+		"hostname": "node-1",
+		"ready": true,
+		"enabled": "false",
+		"port": 8080,
+		"weight": 0.5,
+		"disk_size": 1000000,
+		"timestamp": 1539856800000,
+		"replicas": "3",
+		"ips": ["10.0.0.1", "10.0.0.2"],
+		"proxy_ports": [80, 443],
+		"networks": [
+			{"name": "private", "ip": "10.0.0.1"},
+			{"name": "public", "ip": "172.16.0.1"}
+		],
+		"labels": {"role": "worker"},
+		"cloudify.kubernetes": {"url": "https://10.0.0.1:6443"}
+	}
+}`
+
+func runtimePropertiesTestInstance(t *testing.T) *NodeInstance {
+	var instance NodeInstance
+	if err := json.Unmarshal([]byte(runtimePropertiesInstance), &instance); err != nil {
+		t.Fatalf("Can't parse instance: %s", err.Error())
+	}
+	return &instance
+}
+
+// TestLookupPath - check selection of nested runtime properties
+func TestLookupPath(t *testing.T) {
+	instance := runtimePropertiesTestInstance(t)
+
+	tests.AssertEqual(t, instance.GetPath("networks.1.ip"), "172.16.0.1",
+		"Recheck nested value: %v", instance.GetPath("networks.1.ip"))
+	tests.AssertEqual(t, instance.GetPath("networks[0].name"), "private",
+		"Recheck nested value: %v", instance.GetPath("networks[0].name"))
+	tests.AssertEqual(t, instance.GetPath("labels.role"), "worker",
+		"Recheck nested value: %v", instance.GetPath("labels.role"))
+	tests.AssertEqual(t, instance.GetPath("networks.2.ip"), nil,
+		"Recheck missed value: %v", instance.GetPath("networks.2.ip"))
+	for _, path := range []string{
+		`cloudify\.kubernetes.url`,
+		`["cloudify.kubernetes"].url`,
+		`['cloudify.kubernetes']["url"]`,
+	} {
+		tests.AssertEqual(t, instance.GetPath(path), "https://10.0.0.1:6443",
+			"Recheck key with dot for %s: %v", path, instance.GetPath(path))
+	}
+
+	for path, message := range map[string]string{
+		"":                          "Empty property path",
+		"networks.name":             "Property networks.name: list index expected, got name",
+		"networks.5":                "Property networks.5: index is out of range, list has 2 items",
+		"labels.zone":               "Property labels.zone is not found",
+		"hostname.first":            "Property hostname.first is not found, parent is not map or list",
+		"networks.0.vlan.1":         "Property networks.0.vlan is not found",
+		`cloudify\.kubernetes.host`: `Property cloudify\.kubernetes.host is not found`,
+		`labels["role`:              `Property path labels["role: quote is not closed`,
+		`labels["role"`:             `Property path labels["role": ] expected after quoted key`,
+		"networks[0":                "Property path networks[0: ] is not found",
+		`labels.role\`:              `Property path labels.role\ ends with escape`,
+	} {
+		_, err := instance.LookupPath(path)
+		if err == nil {
+			t.Errorf("Error must be returned for %s", path)
+			continue
+		}
+		tests.AssertEqual(t, err.Error(), message, "Recheck error for %s: %s", path, err.Error())
+	}
+}
+
+// TestTypedPath - check conversion of runtime properties
+func TestTypedPath(t *testing.T) {
+	instance := runtimePropertiesTestInstance(t)
+
+	str, err := instance.GetStringPath("hostname")
+	tests.AssertEqual(t, str, "node-1", "Recheck string: %s (%v)", str, err)
+	str, err = instance.GetStringPath("port")
+	tests.AssertEqual(t, str, "8080", "Recheck string: %s (%v)", str, err)
+	str, err = instance.GetStringPath("disk_size")
+	tests.AssertEqual(t, str, "1000000", "Recheck string: %s (%v)", str, err)
+	str, err = instance.GetStringPath("timestamp")
+	tests.AssertEqual(t, str, "1539856800000", "Recheck string: %s (%v)", str, err)
+	str, err = instance.GetStringPath("weight")
+	tests.AssertEqual(t, str, "0.5", "Recheck string: %s (%v)", str, err)
+	if _, err := instance.GetStringPath("labels"); err == nil {
+		t.Error("Map can't be string")
+	}
+
+	number, err := instance.GetIntPath("port")
+	tests.AssertEqual(t, number, 8080, "Recheck int: %d (%v)", number, err)
+	number, err = instance.GetIntPath("replicas")
+	tests.AssertEqual(t, number, 3, "Recheck int: %d (%v)", number, err)
+	number, err = instance.GetIntPath("proxy_ports.1")
+	tests.AssertEqual(t, number, 443, "Recheck int: %d (%v)", number, err)
+	if _, err := instance.GetIntPath("weight"); err == nil {
+		t.Error("Float can't be int")
+	}
+	if _, err := instance.GetIntPath("hostname"); err == nil {
+		t.Error("Text can't be int")
+	}
+
+	flag, err := instance.GetBoolPath("ready")
+	tests.AssertEqual(t, flag, true, "Recheck bool: %v (%v)", flag, err)
+	flag, err = instance.GetBoolPath("enabled")
+	tests.AssertEqual(t, flag, false, "Recheck bool: %v (%v)", flag, err)
+	if _, err := instance.GetBoolPath("port"); err == nil {
+		t.Error("Number can't be bool")
+	}
+
+	list, err := instance.GetStringListPath("ips")
+	tests.AssertEqual(t, len(list), 2, "Recheck list: %v (%v)", list, err)
+	tests.AssertEqual(t, list[1], "10.0.0.2", "Recheck list: %v", list)
+	if _, err := instance.GetStringListPath("proxy_ports"); err == nil ||
+		err.Error() != "Property proxy_ports.0 is float64, string expected" {
+		t.Errorf("Numbers can't be strings: %v", err)
+	}
+
+	labels, err := instance.GetMapPath("labels")
+	tests.AssertEqual(t, labels["role"], "worker", "Recheck map: %v (%v)", labels, err)
+	if _, err := instance.GetMapPath("ips"); err == nil {
+		t.Error("List can't be map")
+	}
+}
+
+// TestDecodeRuntimeProperties - check decoding runtime properties to struct
+func TestDecodeRuntimeProperties(t *testing.T) {
+	instance := runtimePropertiesTestInstance(t)
+
+	var props struct {
+		Hostname   string   `json:"hostname"`
+		Port       int      `json:"port"`
+		ProxyPorts []int    `json:"proxy_ports"`
+		IPs        []string `json:"ips"`
+		Networks   []struct {
+			Name string `json:"name"`
+			IP   string `json:"ip"`
+		} `json:"networks"`
+	}
+	if err := instance.DecodeRuntimeProperties(&props); err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, props.Hostname, "node-1", "Recheck hostname: %s", props.Hostname)
+	tests.AssertEqual(t, props.ProxyPorts[1], 443, "Recheck ports: %v", props.ProxyPorts)
+	tests.AssertEqual(t, props.Networks[1].IP, "172.16.0.1", "Recheck networks: %v", props.Networks)
+
+	var wrong struct {
+		Port string `json:"port"`
+	}
+	if err := instance.DecodeRuntimeProperties(&wrong); err == nil {
+		t.Error("Decode error must be returned")
+	}
+}
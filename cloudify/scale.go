@@ -0,0 +1,196 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"fmt"
+	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
+)
+
+// UnboundedInstances - max instances value for scaling group or node
+// without upper limit
+const UnboundedInstances = -1
+
+// ScalePlan - checked change of scaling group or node instances count
+type ScalePlan struct {
+	DeploymentID string `json:"deployment_id"`
+	// ScalableEntity - scaling group or node name
+	ScalableEntity string `json:"scalable_entity_name"`
+	// IsGroup - entity is scaling group, otherwise node
+	IsGroup bool `json:"is_group"`
+	Delta   int  `json:"delta"`
+	// Properties - limits and instances count before scale
+	Properties ScalingGroupProperties `json:"properties"`
+	// PlannedInstances - count of instances after scale
+	PlannedInstances int `json:"planned_instances"`
+}
+
+// ScaleResult - result of scale workflow with changed instances
+type ScaleResult struct {
+	Plan ScalePlan `json:"plan"`
+	// Result - scale execution result
+	Result *WaitResult `json:"result"`
+	// Added/Removed - instances created or deleted by scale, filled only
+	// if instances are requested
+	Added   []NodeInstance `json:"added"`
+	Removed []NodeInstance `json:"removed"`
+}
+
+// checkScale - check that planned instances count is inside limits
+func (plan *ScalePlan) checkScale() error {
+	if plan.Delta == 0 {
+		return fmt.Errorf("Delta for %s must not be zero", plan.ScalableEntity)
+	}
+	plan.PlannedInstances = plan.Properties.PlannedInstances + plan.Delta
+	if plan.PlannedInstances < 0 || plan.PlannedInstances < plan.Properties.MinInstances {
+		return fmt.Errorf("Can't scale %s to %d instances, min instances is %d",
+			plan.ScalableEntity, plan.PlannedInstances, plan.Properties.MinInstances)
+	}
+	if plan.Properties.MaxInstances != UnboundedInstances &&
+		plan.PlannedInstances > plan.Properties.MaxInstances {
+		return fmt.Errorf("Can't scale %s to %d instances, max instances is %d",
+			plan.ScalableEntity, plan.PlannedInstances, plan.Properties.MaxInstances)
+	}
+	return nil
+}
+
+// PlanScale - check that scaling group or node can be scaled by delta,
+// node which is member of scaling group (directly or by host) can't be
+// scaled separately
+func (cl *Client) PlanScale(deploymentID, groupOrNode string, delta int) (*ScalePlan, error) {
+	return cl.PlanScaleWithContext(context.Background(), deploymentID, groupOrNode, delta)
+}
+
+// PlanScaleWithContext - check that scaling group or node can be scaled by
+// delta, canceled with context
+func (cl *Client) PlanScaleWithContext(ctx context.Context, deploymentID, groupOrNode string, delta int) (*ScalePlan, error) {
+	plan := ScalePlan{
+		DeploymentID:   deploymentID,
+		ScalableEntity: groupOrNode,
+		Delta:          delta,
+	}
+
+	deployment, err := cl.GetDeploymentWithContext(ctx, deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if scaleGroup, ok := deployment.ScalingGroups[groupOrNode]; ok {
+		plan.IsGroup = true
+		plan.Properties = scaleGroup.Properties
+		return &plan, plan.checkScale()
+	}
+
+	var params = map[string]string{}
+	params["deployment_id"] = deploymentID
+	params["id"] = groupOrNode
+	nodes, err := cl.GetAllNodesWithContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		if node.ID != groupOrNode {
+			continue
+		}
+		for groupName, scaleGroup := range deployment.ScalingGroups {
+			if utils.InList(scaleGroup.Members, node.ID) ||
+				(node.HostID != "" && utils.InList(scaleGroup.Members, node.HostID)) {
+				return nil, fmt.Errorf("Node %s is member of scaling group %s, scale group instead",
+					node.ID, groupName)
+			}
+		}
+		plan.Properties = ScalingGroupProperties{
+			MinInstances:     node.MinNumberOfInstances,
+			PlannedInstances: node.PlannedNumberOfInstances,
+			DefaultInstances: node.DeployNumberOfInstances,
+			MaxInstances:     node.MaxNumberOfInstances,
+			CurrentInstances: node.NumberOfInstances,
+		}
+		return &plan, plan.checkScale()
+	}
+	return nil, fmt.Errorf("No such scale group or node: %s", groupOrNode)
+}
+
+// diffNodeInstances - instances which exist only in after and only in before
+func diffNodeInstances(before, after []NodeInstance) (added, removed []NodeInstance) {
+	beforeIDs := map[string]bool{}
+	for _, instance := range before {
+		beforeIDs[instance.ID] = true
+	}
+	afterIDs := map[string]bool{}
+	added = []NodeInstance{}
+	for _, instance := range after {
+		afterIDs[instance.ID] = true
+		if !beforeIDs[instance.ID] {
+			added = append(added, instance)
+		}
+	}
+	removed = []NodeInstance{}
+	for _, instance := range before {
+		if !afterIDs[instance.ID] {
+			removed = append(removed, instance)
+		}
+	}
+	return added, removed
+}
+
+// ScaleGroup - check limits, run scale workflow for scaling group or node
+// and wait for finish. Negative delta removes instances. Instances added or
+// removed by scale are returned if includeInstances is set.
+func (cl *Client) ScaleGroup(deploymentID, groupOrNode string, delta int, includeInstances bool) (*ScaleResult, error) {
+	return cl.ScaleGroupWithContext(context.Background(), deploymentID, groupOrNode, delta, includeInstances)
+}
+
+// ScaleGroupWithContext - check limits, run scale workflow and wait for
+// finish, canceled with context. Failed execution is returned as
+// LifecycleError with execution result.
+func (cl *Client) ScaleGroupWithContext(ctx context.Context, deploymentID, groupOrNode string, delta int, includeInstances bool) (*ScaleResult, error) {
+	plan, err := cl.PlanScaleWithContext(ctx, deploymentID, groupOrNode, delta)
+	if err != nil {
+		return nil, &LifecycleError{Step: StepScale, Err: err}
+	}
+	scale := ScaleResult{Plan: *plan}
+
+	var params = map[string]string{}
+	params["deployment_id"] = deploymentID
+	var before *NodeInstances
+	if includeInstances {
+		before, err = cl.GetAllNodeInstancesWithContext(ctx, params)
+		if err != nil {
+			return nil, &LifecycleError{Step: StepScale, Err: err}
+		}
+	}
+
+	cl.reportStep(StepScale, "scale %s from %d to %d instances",
+		groupOrNode, plan.Properties.PlannedInstances, plan.PlannedInstances)
+	scale.Result, err = cl.runWorkflow(ctx, StepScale, deploymentID, "scale", map[string]interface{}{
+		"scalable_entity_name": groupOrNode,
+		"delta":                delta,
+	})
+	if err != nil {
+		return &scale, err
+	}
+
+	if includeInstances {
+		after, err := cl.GetAllNodeInstancesWithContext(ctx, params)
+		if err != nil {
+			return &scale, &LifecycleError{Step: StepScale, Err: err, Result: scale.Result}
+		}
+		scale.Added, scale.Removed = diffNodeInstances(before.Items, after.Items)
+	}
+	return &scale, nil
+}
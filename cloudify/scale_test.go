@@ -0,0 +1,162 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"encoding/json"
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"strings"
+	"testing"
+)
+
+const scaleDeploymentResponce = `{
+	"id": "deployment",
+	"scaling_groups": {
+		"k8s_node_scale_group": {
+			"members": ["k8s_node_host"],
+			"properties": {
+				"min_instances": 1, "max_instances": 3, "planned_instances": 2,
+				"default_instances": 1, "current_instances": 2
+			}
+		}
+	}
+}`
+
+const scaleNodesResponce = `{"items": [{
+	"id": "k8s_node", "host_id": "k8s_node_host",
+	"min_number_of_instances": 0, "max_number_of_instances": -1,
+	"planned_number_of_instances": 2, "number_of_instances": 2
+}, {
+	"id": "proxy", "host_id": "proxy",
+	"min_number_of_instances": 1, "max_number_of_instances": -1,
+	"planned_number_of_instances": 1, "number_of_instances": 1
+}], "metadata": {"pagination": {"total": 2, "offset": 0, "size": 2}}}`
+
+// scaleClient - fake client with responses selected by url, node
+// instances list is changed after first request
+type scaleClient struct {
+	tests.FakeClient
+	instances []string
+}
+
+func (cl *scaleClient) GetWithContext(ctx context.Context, url, acceptedContentType string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(url, "deployments/"):
+		cl.GetResponse = []byte(scaleDeploymentResponce)
+	case strings.HasPrefix(url, "nodes?"):
+		cl.GetResponse = []byte(scaleNodesResponce)
+	case strings.HasPrefix(url, "node-instances?"):
+		cl.GetResponse = []byte(cl.instances[0])
+		if len(cl.instances) > 1 {
+			cl.instances = cl.instances[1:]
+		}
+	default:
+		cl.GetResponse = []byte(`{"items": [], "metadata": {"pagination": {"total": 0}}}`)
+	}
+	return cl.FakeClient.GetWithContext(ctx, url, acceptedContentType)
+}
+
+// TestPlanScale - check scale limits
+func TestPlanScale(t *testing.T) {
+	var conn scaleClient
+	cl := ClientFromConnection(&conn)
+
+	plan, err := cl.PlanScale("deployment", "k8s_node_scale_group", 1)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, plan.IsGroup, true, "Recheck group: %+v", plan)
+	tests.AssertEqual(t, plan.PlannedInstances, 3, "Recheck planned instances: %+v", plan)
+
+	plan, err = cl.PlanScale("deployment", "proxy", 5)
+	if err != nil {
+		t.Errorf("Unbounded node must be scaled: %v", err)
+		return
+	}
+	tests.AssertEqual(t, plan.IsGroup, false, "Recheck group: %+v", plan)
+	tests.AssertEqual(t, plan.PlannedInstances, 6, "Recheck planned instances: %+v", plan)
+
+	for _, check := range []struct {
+		name    string
+		delta   int
+		message string
+	}{
+		{"k8s_node_scale_group", 2, "Can't scale k8s_node_scale_group to 4 instances, max instances is 3"},
+		{"k8s_node_scale_group", -2, "Can't scale k8s_node_scale_group to 0 instances, min instances is 1"},
+		{"k8s_node_scale_group", 0, "Delta for k8s_node_scale_group must not be zero"},
+		{"proxy", -1, "Can't scale proxy to 0 instances, min instances is 1"},
+		{"k8s_node", 1, "Node k8s_node is member of scaling group k8s_node_scale_group, scale group instead"},
+		{"unknown", 1, "No such scale group or node: unknown"},
+	} {
+		_, err := cl.PlanScale("deployment", check.name, check.delta)
+		if err == nil {
+			t.Errorf("Error must be returned for %s %d", check.name, check.delta)
+			continue
+		}
+		tests.AssertEqual(t, err.Error(), check.message, "Recheck error: %s", err.Error())
+	}
+}
+
+// TestScaleGroup - check scale workflow parameters and changed instances
+func TestScaleGroup(t *testing.T) {
+	conn := scaleClient{instances: []string{
+		`{"items": [{"id": "k8s_node_host_a"}, {"id": "k8s_node_host_b"}]}`,
+		`{"items": [{"id": "k8s_node_host_b"}, {"id": "k8s_node_host_c"}, {"id": "k8s_node_c"}]}`,
+	}}
+	conn.PostResponse = []byte(`{"id": "scale", "workflow_id": "scale", "status": "terminated"}`)
+	cl := ClientFromConnection(&conn)
+
+	scale, err := cl.ScaleGroup("deployment", "k8s_node_scale_group", 1, true)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	var exec ExecutionPost
+	if err := json.Unmarshal(conn.PostData, &exec); err != nil {
+		t.Errorf("Can't parse execution: %v", err)
+		return
+	}
+	tests.AssertEqual(t, exec.WorkflowID, "scale", "Recheck workflow: %s", exec.WorkflowID)
+	tests.AssertEqual(t, exec.Parameters["scalable_entity_name"], "k8s_node_scale_group",
+		"Recheck parameters: %v", exec.Parameters)
+	tests.AssertEqual(t, exec.Parameters["delta"], float64(1),
+		"Recheck parameters: %v", exec.Parameters)
+	tests.AssertEqual(t, scale.Result.State, WaitTerminated,
+		"Recheck execution state: %s", scale.Result.State)
+	tests.AssertEqual(t, len(scale.Added), 2, "Recheck added: %v", scale.Added)
+	tests.AssertEqual(t, scale.Added[0].ID, "k8s_node_host_c", "Recheck added: %v", scale.Added)
+	tests.AssertEqual(t, len(scale.Removed), 1, "Recheck removed: %v", scale.Removed)
+	tests.AssertEqual(t, scale.Removed[0].ID, "k8s_node_host_a", "Recheck removed: %v", scale.Removed)
+
+	conn.PostURL = ""
+	_, err = cl.ScaleGroup("deployment", "k8s_node_scale_group", 5, true)
+	if lifecycleErr, ok := err.(*LifecycleError); !ok || lifecycleErr.Step != StepScale {
+		t.Errorf("Recheck error type: %v", err)
+	}
+	tests.AssertEqual(t, conn.PostURL, "", "Workflow must not be started: %s", conn.PostURL)
+
+	conn.PostResponse = []byte(executionFailedResponce)
+	scale, err = cl.ScaleGroup("deployment", "proxy", 1, false)
+	if lifecycleErr, ok := err.(*LifecycleError); !ok || lifecycleErr.Result == nil {
+		t.Errorf("Recheck error type: %v", err)
+		return
+	}
+	tests.AssertEqual(t, scale.Result.State, WaitFailed,
+		"Recheck execution state: %s", scale.Result.State)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	"fmt"
 	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 )
@@ -29,23 +30,33 @@ const KubernetesNode = "cloudify.nodes.ApplicationServer.kubernetes.Node"
 
 // GetDeployment - return deployment by ID
 func (cl *Client) GetDeployment(deploymentID string) (*Deployment, error) {
-	var params = map[string]string{}
-	params["id"] = deploymentID
-	deployments, err := cl.GetDeployments(params)
+	return cl.GetDeploymentWithContext(context.Background(), deploymentID)
+}
+
+// GetDeploymentWithContext - return deployment by ID, canceled with context
+func (cl *Client) GetDeploymentWithContext(ctx context.Context, deploymentID string) (*Deployment, error) {
+	var deployment DeploymentGet
+
+	// manager returns not found error for unknown deployment,
+	// check by rest.IsNotFound
+	err := cl.GetWithContext(ctx, "deployments/"+deploymentID, &deployment)
 	if err != nil {
 		return nil, err
 	}
-	if len(deployments.Items) != 1 {
-		return nil, fmt.Errorf("Returned wrong count of deployments:%+v", deploymentID)
-	}
-	return &deployments.Items[0], nil
+
+	return &deployment.Deployment, nil
 }
 
 // GetDeploymentInstancesHostGrouped - return instances grouped by host
 func (cl *Client) GetDeploymentInstancesHostGrouped(params map[string]string) (map[string]NodeInstances, error) {
+	return cl.GetDeploymentInstancesHostGroupedWithContext(context.Background(), params)
+}
+
+// GetDeploymentInstancesHostGroupedWithContext - return instances grouped by host, canceled with context
+func (cl *Client) GetDeploymentInstancesHostGroupedWithContext(ctx context.Context, params map[string]string) (map[string]NodeInstances, error) {
 	var result = map[string]NodeInstances{}
 
-	nodeInstances, err := cl.GetNodeInstances(params)
+	nodeInstances, err := cl.GetAllNodeInstancesWithContext(ctx, params)
 	if err != nil {
 		return result, err
 	}
@@ -75,9 +86,14 @@ func (cl *Client) GetDeploymentInstancesHostGrouped(params map[string]string) (m
 
 // GetDeploymentInstancesNodeGrouped - return instances grouped by node
 func (cl *Client) GetDeploymentInstancesNodeGrouped(params map[string]string) (map[string]NodeInstances, error) {
+	return cl.GetDeploymentInstancesNodeGroupedWithContext(context.Background(), params)
+}
+
+// GetDeploymentInstancesNodeGroupedWithContext - return instances grouped by node, canceled with context
+func (cl *Client) GetDeploymentInstancesNodeGroupedWithContext(ctx context.Context, params map[string]string) (map[string]NodeInstances, error) {
 	var result = map[string]NodeInstances{}
 
-	nodeInstances, err := cl.GetNodeInstances(params)
+	nodeInstances, err := cl.GetAllNodeInstancesWithContext(ctx, params)
 	if err != nil {
 		return result, err
 	}
@@ -127,7 +143,12 @@ func (cl *Client) listNodeToNodes(nodes []Node) *Nodes {
 // GetNodeInstancesWithType - Returned list of started node instances with some node type,
 // used mainly for kubernetes, also check that all instances related to same hostId started
 func (cl *Client) GetNodeInstancesWithType(params map[string]string, nodeType string) (*NodeInstances, error) {
-	nodeInstances, err := cl.GetNodeInstances(params)
+	return cl.GetNodeInstancesWithTypeWithContext(context.Background(), params, nodeType)
+}
+
+// GetNodeInstancesWithTypeWithContext - Returned list of started node instances with some node type, canceled with context
+func (cl *Client) GetNodeInstancesWithTypeWithContext(ctx context.Context, params map[string]string, nodeType string) (*NodeInstances, error) {
+	nodeInstances, err := cl.GetAllNodeInstancesWithContext(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +157,7 @@ func (cl *Client) GetNodeInstancesWithType(params map[string]string, nodeType st
 	if val, ok := params["deployment_id"]; ok {
 		nodeParams["deployment_id"] = val
 	}
-	nodes, err := cl.GetNodes(nodeParams)
+	nodes, err := cl.GetAllNodesWithContext(ctx, nodeParams)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +179,12 @@ func (cl *Client) GetNodeInstancesWithType(params map[string]string, nodeType st
 // used mainly for kubernetes, need to get instances that can be joined to cluster
 // Useful for cloudprovider logic only.
 func (cl *Client) GetAliveNodeInstancesWithType(params map[string]string, nodeType string) (*NodeInstances, error) {
-	nodeInstances, err := cl.GetNodeInstancesWithType(params, nodeType)
+	return cl.GetAliveNodeInstancesWithTypeWithContext(context.Background(), params, nodeType)
+}
+
+// GetAliveNodeInstancesWithTypeWithContext - Returned list of alive node instances with some node type, canceled with context
+func (cl *Client) GetAliveNodeInstancesWithTypeWithContext(ctx context.Context, params map[string]string, nodeType string) (*NodeInstances, error) {
+	nodeInstances, err := cl.GetNodeInstancesWithTypeWithContext(ctx, params, nodeType)
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +210,12 @@ func (cl *Client) GetAliveNodeInstancesWithType(params map[string]string, nodeTy
 // used mainly for kubernetes, also check that all instances related to same hostId started
 // Useful for scale only.
 func (cl *Client) GetStartedNodeInstancesWithType(params map[string]string, nodeType string) (*NodeInstances, error) {
-	nodeInstancesGrouped, err := cl.GetDeploymentInstancesHostGrouped(params)
+	return cl.GetStartedNodeInstancesWithTypeWithContext(context.Background(), params, nodeType)
+}
+
+// GetStartedNodeInstancesWithTypeWithContext - Returned list of started node instances with some node type, canceled with context
+func (cl *Client) GetStartedNodeInstancesWithTypeWithContext(ctx context.Context, params map[string]string, nodeType string) (*NodeInstances, error) {
+	nodeInstancesGrouped, err := cl.GetDeploymentInstancesHostGroupedWithContext(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +224,7 @@ func (cl *Client) GetStartedNodeInstancesWithType(params map[string]string, node
 	if val, ok := params["deployment_id"]; ok {
 		nodeParams["deployment_id"] = val
 	}
-	nodes, err := cl.GetNodes(nodeParams)
+	nodes, err := cl.GetAllNodesWithContext(ctx, nodeParams)
 	if err != nil {
 		return nil, err
 	}
@@ -220,7 +251,12 @@ func (cl *Client) GetStartedNodeInstancesWithType(params map[string]string, node
 
 // GetDeploymentScaleGroup - return scaling group by name and deployment
 func (cl *Client) GetDeploymentScaleGroup(deploymentID, scaleGroupName string) (*ScalingGroup, error) {
-	deployment, err := cl.GetDeployment(deploymentID)
+	return cl.GetDeploymentScaleGroupWithContext(context.Background(), deploymentID, scaleGroupName)
+}
+
+// GetDeploymentScaleGroupWithContext - return scaling group by name and deployment, canceled with context
+func (cl *Client) GetDeploymentScaleGroupWithContext(ctx context.Context, deploymentID, scaleGroupName string) (*ScalingGroup, error) {
+	deployment, err := cl.GetDeploymentWithContext(ctx, deploymentID)
 	if err != nil {
 		return nil, err
 	}
@@ -236,16 +272,21 @@ func (cl *Client) GetDeploymentScaleGroup(deploymentID, scaleGroupName string) (
 
 // GetDeploymentScaleGroupNodes - return nodes related to scaling group
 func (cl *Client) GetDeploymentScaleGroupNodes(deploymentID, groupName, nodeType string) (*Nodes, error) {
+	return cl.GetDeploymentScaleGroupNodesWithContext(context.Background(), deploymentID, groupName, nodeType)
+}
+
+// GetDeploymentScaleGroupNodesWithContext - return nodes related to scaling group, canceled with context
+func (cl *Client) GetDeploymentScaleGroupNodesWithContext(ctx context.Context, deploymentID, groupName, nodeType string) (*Nodes, error) {
 	// get all nodes
 	params := map[string]string{}
 	params["deployment_id"] = deploymentID
-	cloudNodes, err := cl.GetStartedNodesWithType(params, nodeType)
+	cloudNodes, err := cl.GetStartedNodesWithTypeWithContext(ctx, params, nodeType)
 	if err != nil {
 		return nil, err
 	}
 
 	// get scale group
-	scaleGroup, err := cl.GetDeploymentScaleGroup(deploymentID, groupName)
+	scaleGroup, err := cl.GetDeploymentScaleGroupWithContext(ctx, deploymentID, groupName)
 	if err != nil {
 		return nil, err
 	}
@@ -265,16 +306,21 @@ func (cl *Client) GetDeploymentScaleGroupNodes(deploymentID, groupName, nodeType
 
 // GetDeploymentScaleGroupInstances - return instances related to scaling group
 func (cl *Client) GetDeploymentScaleGroupInstances(deploymentID, groupName, nodeType string) (*NodeInstances, error) {
+	return cl.GetDeploymentScaleGroupInstancesWithContext(context.Background(), deploymentID, groupName, nodeType)
+}
+
+// GetDeploymentScaleGroupInstancesWithContext - return instances related to scaling group, canceled with context
+func (cl *Client) GetDeploymentScaleGroupInstancesWithContext(ctx context.Context, deploymentID, groupName, nodeType string) (*NodeInstances, error) {
 	// get all instances
 	params := map[string]string{}
 	params["deployment_id"] = deploymentID
-	cloudInstances, err := cl.GetStartedNodeInstancesWithType(params, nodeType)
+	cloudInstances, err := cl.GetStartedNodeInstancesWithTypeWithContext(ctx, params, nodeType)
 	if err != nil {
 		return nil, err
 	}
 
 	// get nodes in scale group (need to get nodes because we need host for each)
-	cloudNodes, err := cl.GetDeploymentScaleGroupNodes(deploymentID, groupName, nodeType)
+	cloudNodes, err := cl.GetDeploymentScaleGroupNodesWithContext(ctx, deploymentID, groupName, nodeType)
 	if err != nil {
 		return nil, err
 	}
@@ -294,21 +340,26 @@ func (cl *Client) GetDeploymentScaleGroupInstances(deploymentID, groupName, node
 
 // GetDeploymentInstancesScaleGrouped - return instances grouped by scaleing group
 func (cl *Client) GetDeploymentInstancesScaleGrouped(deploymentID, nodeType string) (map[string]NodeInstances, error) {
+	return cl.GetDeploymentInstancesScaleGroupedWithContext(context.Background(), deploymentID, nodeType)
+}
+
+// GetDeploymentInstancesScaleGroupedWithContext - return instances grouped by scaleing group, canceled with context
+func (cl *Client) GetDeploymentInstancesScaleGroupedWithContext(ctx context.Context, deploymentID, nodeType string) (map[string]NodeInstances, error) {
 	var result = map[string]NodeInstances{}
 
-	deployment, err := cl.GetDeployment(deploymentID)
+	deployment, err := cl.GetDeploymentWithContext(ctx, deploymentID)
 	if err != nil {
 		return result, err
 	}
 
 	var params = map[string]string{}
 	params["deployment_id"] = deploymentID
-	nodes, err := cl.GetStartedNodesWithType(params, nodeType)
+	nodes, err := cl.GetStartedNodesWithTypeWithContext(ctx, params, nodeType)
 	if err != nil {
 		return result, err
 	}
 
-	cloudInstances, err := cl.GetStartedNodeInstancesWithType(params, nodeType)
+	cloudInstances, err := cl.GetStartedNodeInstancesWithTypeWithContext(ctx, params, nodeType)
 	if err != nil {
 		return result, err
 	}
@@ -17,6 +17,7 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
 )
@@ -35,27 +36,49 @@ type NodeWithGroups struct {
 	Items    []NodeWithGroup `json:"items"`
 }
 
-// SelfUpdateGroups - go by nodes and update group if we have some additional info from parent
+// SelfUpdateGroups - go by nodes and update group if we have some additional info
+// from containers, nearest container with group is used
 func (nwg *NodeWithGroups) SelfUpdateGroups() {
+	// node ids are unique only inside deployment
+	deploymentNodes := map[string][]Node{}
+	positions := map[string]map[string]int{}
+	for pos, node := range nwg.Items {
+		deploymentNodes[node.DeploymentID] = append(deploymentNodes[node.DeploymentID], node.Node)
+		if positions[node.DeploymentID] == nil {
+			positions[node.DeploymentID] = map[string]int{}
+		}
+		positions[node.DeploymentID][node.ID] = pos
+	}
+	topologies := map[string]*Topology{}
+	for deploymentID, nodes := range deploymentNodes {
+		topologies[deploymentID] = NewNodesTopology(nodes)
+	}
+
 	for childInd, child := range nwg.Items {
-		if child.HostID != child.ID {
-			// skip filled
-			if child.GroupName != "" &&
-				child.ScalingGroupName != "" {
+		// skip filled
+		if child.GroupName != "" &&
+			child.ScalingGroupName != "" {
+			continue
+		}
+
+		containers := topologies[child.DeploymentID].ContainedIn(child.ID)
+		if len(containers) == 0 && child.HostID != "" && child.HostID != child.ID {
+			// relationships are not returned by manager
+			containers = []string{child.HostID}
+		}
+
+		// go by containers
+		for _, containerID := range containers {
+			hostInd, ok := positions[child.DeploymentID][containerID]
+			if !ok {
 				continue
 			}
-
-			// go by childs
-			for _, host := range nwg.Items {
-				if child.HostID == host.ID &&
-					child.DeploymentID == host.DeploymentID {
-					if child.GroupName == "" {
-						nwg.Items[childInd].GroupName = host.GroupName
-					}
-					if child.ScalingGroupName == "" {
-						nwg.Items[childInd].ScalingGroupName = host.ScalingGroupName
-					}
-				}
+			host := nwg.Items[hostInd]
+			if nwg.Items[childInd].GroupName == "" {
+				nwg.Items[childInd].GroupName = host.GroupName
+			}
+			if nwg.Items[childInd].ScalingGroupName == "" {
+				nwg.Items[childInd].ScalingGroupName = host.ScalingGroupName
 			}
 		}
 	}
@@ -63,11 +86,16 @@ func (nwg *NodeWithGroups) SelfUpdateGroups() {
 
 // GetNodesFull - return nodes filtered by params
 func (cl *Client) GetNodesFull(params map[string]string) (*NodeWithGroups, error) {
+	return cl.GetNodesFullWithContext(context.Background(), params)
+}
+
+// GetNodesFullWithContext - return nodes filtered by params, canceled with context
+func (cl *Client) GetNodesFullWithContext(ctx context.Context, params map[string]string) (*NodeWithGroups, error) {
 	var nodeWithGroups NodeWithGroups
 
 	deploymentParams := map[string]string{}
 
-	nodes, err := cl.GetNodes(params)
+	nodes, err := cl.GetAllNodesWithContext(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +104,7 @@ func (cl *Client) GetNodesFull(params map[string]string) (*NodeWithGroups, error
 		deploymentParams["id"] = value
 	}
 
-	deployments, err := cl.GetDeployments(deploymentParams)
+	deployments, err := cl.GetDeploymentsWithContext(ctx, deploymentParams)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +146,12 @@ func (cl *Client) GetNodesFull(params map[string]string) (*NodeWithGroups, error
 
 // GetStartedNodesWithType - return nodes specified type with more than zero instances
 func (cl *Client) GetStartedNodesWithType(params map[string]string, nodeType string) (*Nodes, error) {
-	cloudNodes, err := cl.GetNodes(params)
+	return cl.GetStartedNodesWithTypeWithContext(context.Background(), params, nodeType)
+}
+
+// GetStartedNodesWithTypeWithContext - return nodes specified type with more than zero instances, canceled with context
+func (cl *Client) GetStartedNodesWithTypeWithContext(ctx context.Context, params map[string]string, nodeType string) (*Nodes, error) {
+	cloudNodes, err := cl.GetAllNodesWithContext(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,203 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+)
+
+// Visibility levels of manager resources
+const (
+	VisibilityPrivate = "private"
+	VisibilityTenant  = "tenant"
+	VisibilityGlobal  = "global"
+)
+
+// SecretPost - information for create or update secret
+type SecretPost struct {
+	Value string `json:"value"`
+	// update value if secret with same key already exists
+	UpdateIfExists bool `json:"update_if_exists"`
+	// one of private/tenant/global, manager default is used if empty
+	Visibility string `json:"visibility,omitempty"`
+	// hide value from users without owner permissions
+	IsHiddenValue bool `json:"is_hidden_value"`
+}
+
+// SecretPatch - new value and settings of existed secret,
+// fields without value are kept unchanged
+type SecretPatch struct {
+	Value         *string `json:"value,omitempty"`
+	Visibility    string  `json:"visibility,omitempty"`
+	IsHiddenValue *bool   `json:"is_hidden_value,omitempty"`
+}
+
+// Secret - information about secret stored on manager,
+// value is returned only by get secret call
+type Secret struct {
+	Key           string `json:"key"`
+	Value         string `json:"value,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	Visibility    string `json:"visibility"`
+	IsHiddenValue bool   `json:"is_hidden_value"`
+	Tenant        string `json:"tenant_name"`
+	CreatedBy     string `json:"created_by"`
+}
+
+// SecretGet - response from manager about selected secret
+type SecretGet struct {
+	rest.BaseMessage
+	Secret
+}
+
+// Secrets - response from manager with secrets list
+type Secrets struct {
+	rest.BaseMessage
+	Metadata rest.Metadata `json:"metadata"`
+	Items    []Secret      `json:"items"`
+}
+
+// GetSecrets - return secrets filtered by params, values are not included
+func (cl *Client) GetSecrets(params map[string]string) (*Secrets, error) {
+	return cl.GetSecretsWithContext(context.Background(), params)
+}
+
+// GetSecretsWithContext - return secrets filtered by params, canceled with context
+func (cl *Client) GetSecretsWithContext(ctx context.Context, params map[string]string) (*Secrets, error) {
+	var secrets Secrets
+
+	values := cl.stringMapToURLValue(params)
+
+	err := cl.GetWithContext(ctx, "secrets?"+values.Encode(), &secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secrets, nil
+}
+
+// IterateSecrets - call handler for each secret filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateSecrets(ctx context.Context, params map[string]string, handler func(Secret) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		secrets, err := cl.GetSecretsWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range secrets.Items {
+			if !handler(item) {
+				return secrets.Metadata.Pagination, len(secrets.Items), false, nil
+			}
+		}
+		return secrets.Metadata.Pagination, len(secrets.Items), true, nil
+	})
+}
+
+// GetAllSecrets - return secrets from all pages filtered by params
+func (cl *Client) GetAllSecrets(params map[string]string) (*Secrets, error) {
+	return cl.GetAllSecretsWithContext(context.Background(), params)
+}
+
+// GetAllSecretsWithContext - return secrets from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllSecretsWithContext(ctx context.Context, params map[string]string) (*Secrets, error) {
+	var secrets Secrets
+
+	err := cl.IterateSecrets(ctx, params, func(item Secret) bool {
+		secrets.Items = append(secrets.Items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	secrets.Metadata = allItemsMetadata(len(secrets.Items))
+	return &secrets, nil
+}
+
+// GetSecret - return secret with value by key
+func (cl *Client) GetSecret(key string) (*Secret, error) {
+	return cl.GetSecretWithContext(context.Background(), key)
+}
+
+// GetSecretWithContext - return secret with value by key, canceled with context
+func (cl *Client) GetSecretWithContext(ctx context.Context, key string) (*Secret, error) {
+	var secret SecretGet
+
+	err := cl.GetWithContext(ctx, "secrets/"+key, &secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret.Secret, nil
+}
+
+// CreateSecret - create secret, fails if secret already exists and
+// UpdateIfExists is not set
+func (cl *Client) CreateSecret(key string, secret SecretPost) (*Secret, error) {
+	return cl.CreateSecretWithContext(context.Background(), key, secret)
+}
+
+// CreateSecretWithContext - create secret, canceled with context
+func (cl *Client) CreateSecretWithContext(ctx context.Context, key string, secret SecretPost) (*Secret, error) {
+	var created SecretGet
+
+	err := cl.PutWithContext(ctx, "secrets/"+key, secret, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created.Secret, nil
+}
+
+// UpdateSecret - change value and visibility of existed secret
+func (cl *Client) UpdateSecret(key string, secret SecretPatch) (*Secret, error) {
+	return cl.UpdateSecretWithContext(context.Background(), key, secret)
+}
+
+// UpdateSecretWithContext - change value and visibility of existed secret,
+// canceled with context. Secret is not created, manager returns not found
+// error (check by rest.IsNotFound) for unknown key.
+func (cl *Client) UpdateSecretWithContext(ctx context.Context, key string, secret SecretPatch) (*Secret, error) {
+	var updated SecretGet
+
+	err := cl.PatchWithContext(ctx, "secrets/"+key, secret, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated.Secret, nil
+}
+
+// DeleteSecret - delete secret by key
+func (cl *Client) DeleteSecret(key string) (*Secret, error) {
+	return cl.DeleteSecretWithContext(context.Background(), key)
+}
+
+// DeleteSecretWithContext - delete secret by key, canceled with context
+func (cl *Client) DeleteSecretWithContext(ctx context.Context, key string) (*Secret, error) {
+	var secret SecretGet
+
+	err := cl.DeleteWithContext(ctx, "secrets/"+key, nil, &secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret.Secret, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"testing"
+)
+
+const secretResponce = `{
+	"key": "aws_secret",
+	"value": "",
+	"visibility": "tenant",
+	"is_hidden_value": true,
+	"tenant_name": "default_tenant",
+	"created_by": "admin"
+}`
+
+// TestCreateSecret - check request for create/update secret
+func TestCreateSecret(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PutResponse = []byte(secretResponce)
+	conn.GetResponse = []byte(secretResponce)
+	cl := ClientFromConnection(&conn)
+
+	secret, err := cl.CreateSecret("aws_secret", SecretPost{
+		Value: "password", Visibility: VisibilityTenant, IsHiddenValue: true,
+	})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PutURL, "secrets/aws_secret",
+		"Recheck url for secret: %s", conn.PutURL)
+	tests.AssertEqual(t, string(conn.PutData),
+		`{"value":"password","update_if_exists":false,"visibility":"tenant","is_hidden_value":true}`,
+		"Recheck secret request: %s", string(conn.PutData))
+	tests.AssertEqual(t, secret.IsHiddenValue, true,
+		"Recheck hidden value flag: %v", secret.IsHiddenValue)
+
+}
+
+// TestUpdateSecret - check that update changes only existed secret
+// and sends only changed fields
+func TestUpdateSecret(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PatchResponse = []byte(secretResponce)
+	cl := ClientFromConnection(&conn)
+
+	value := "new"
+	_, err := cl.UpdateSecret("aws_secret", SecretPatch{Value: &value})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PatchURL, "secrets/aws_secret",
+		"Recheck url for secret: %s", conn.PatchURL)
+	tests.AssertEqual(t, string(conn.PatchData), `{"value":"new"}`,
+		"Recheck secret request: %s", string(conn.PatchData))
+
+	hidden := false
+	_, err = cl.UpdateSecret("aws_secret", SecretPatch{
+		Visibility: VisibilityGlobal, IsHiddenValue: &hidden,
+	})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, string(conn.PatchData),
+		`{"visibility":"global","is_hidden_value":false}`,
+		"Recheck secret request: %s", string(conn.PatchData))
+
+	conn.PatchError = &rest.APIError{Status: 404, Code: "not_found_error"}
+	if _, err := cl.UpdateSecret("unknown", SecretPatch{Value: &value}); !rest.IsNotFound(err) {
+		t.Errorf("Not found error must be returned: %v", err)
+	}
+	tests.AssertEqual(t, conn.PutURL, "", "Secret must not be created: %s", conn.PutURL)
+}
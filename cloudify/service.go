@@ -21,6 +21,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"strconv"
 )
 
 // ServiceConfig - settings for connect to cloudify
@@ -35,8 +36,17 @@ func ServiceClientInit(config io.Reader) (*ServiceConfig, error) {
 	cloudConfig.User = os.Getenv("CFY_USER")
 	cloudConfig.Password = os.Getenv("CFY_PASSWORD")
 	cloudConfig.Tenant = os.Getenv("CFY_TENANT")
+	cloudConfig.Token = os.Getenv("CFY_TOKEN")
+	cloudConfig.SessionToken, _ = strconv.ParseBool(os.Getenv("CFY_SESSION_TOKEN"))
 	cloudConfig.AgentFile = os.Getenv("CFY_AGENT")
 	cloudConfig.DeploymentsFile = os.Getenv("CFY_DEPLOYMENTS")
+	cloudConfig.RetryAttempts, _ = strconv.Atoi(os.Getenv("CFY_RETRY_ATTEMPTS"))
+	cloudConfig.CACertFile = os.Getenv("CFY_CA_CERT")
+	cloudConfig.ClientCertFile = os.Getenv("CFY_CLIENT_CERT")
+	cloudConfig.ClientKeyFile = os.Getenv("CFY_CLIENT_KEY")
+	cloudConfig.TLSServerName = os.Getenv("CFY_TLS_SERVER_NAME")
+	cloudConfig.Insecure, _ = strconv.ParseBool(os.Getenv("CFY_INSECURE"))
+	cloudConfig.CertFingerprint = os.Getenv("CFY_CERT_FINGERPRINT")
 
 	// TODO Add support
 	//cloudConfig.NodeType = os.Getenv("CFY_NODE_TYPE")
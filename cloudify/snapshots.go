@@ -0,0 +1,275 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"fmt"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	"os"
+)
+
+// Snapshot statuses returned by manager
+const (
+	SnapshotCreating = "creating"
+	SnapshotCreated  = "created"
+	SnapshotFailed   = "failed"
+	SnapshotUploaded = "uploaded"
+)
+
+// Snapshot - information about manager snapshot
+type Snapshot struct {
+	rest.ObjectIDWithTenant
+	CreatedAt    string `json:"created_at"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error"`
+}
+
+// SnapshotGet - response from manager about selected snapshot
+type SnapshotGet struct {
+	// can be response from api, without int status field
+	// because snapshot has own string status
+	rest.CommonMessage
+	Snapshot
+}
+
+// Snapshots - response from manager with snapshots list
+type Snapshots struct {
+	rest.BaseMessage
+	Metadata rest.Metadata `json:"metadata"`
+	Items    []Snapshot    `json:"items"`
+}
+
+// SnapshotPost - options for create snapshot
+type SnapshotPost struct {
+	IncludeMetrics     bool `json:"include_metrics"`
+	IncludeCredentials bool `json:"include_credentials"`
+	IncludeLogs        bool `json:"include_logs"`
+	IncludeEvents      bool `json:"include_events"`
+	// run snapshot creation after currently running executions
+	Queue bool `json:"queue"`
+}
+
+// SnapshotRestorePost - options for restore snapshot
+type SnapshotRestorePost struct {
+	CallWithForce
+	// restore snapshot from old manager version to tenant
+	Tenant                  string `json:"tenant_name,omitempty"`
+	RestoreCertificates     bool   `json:"restore_certificates"`
+	NoReboot                bool   `json:"no_reboot"`
+	RecreateDeploymentsEnvs bool   `json:"recreate_deployments_envs"`
+	IgnorePluginFailure     bool   `json:"ignore_plugin_failure"`
+	Timeout                 int    `json:"timeout,omitempty"`
+}
+
+// GetSnapshots - return snapshots filtered by params
+func (cl *Client) GetSnapshots(params map[string]string) (*Snapshots, error) {
+	return cl.GetSnapshotsWithContext(context.Background(), params)
+}
+
+// GetSnapshotsWithContext - return snapshots filtered by params, canceled with context
+func (cl *Client) GetSnapshotsWithContext(ctx context.Context, params map[string]string) (*Snapshots, error) {
+	var snapshots Snapshots
+
+	values := cl.stringMapToURLValue(params)
+
+	err := cl.GetWithContext(ctx, "snapshots?"+values.Encode(), &snapshots)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshots, nil
+}
+
+// IterateSnapshots - call handler for each snapshot filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateSnapshots(ctx context.Context, params map[string]string, handler func(Snapshot) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		snapshots, err := cl.GetSnapshotsWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range snapshots.Items {
+			if !handler(item) {
+				return snapshots.Metadata.Pagination, len(snapshots.Items), false, nil
+			}
+		}
+		return snapshots.Metadata.Pagination, len(snapshots.Items), true, nil
+	})
+}
+
+// GetAllSnapshots - return snapshots from all pages filtered by params
+func (cl *Client) GetAllSnapshots(params map[string]string) (*Snapshots, error) {
+	return cl.GetAllSnapshotsWithContext(context.Background(), params)
+}
+
+// GetAllSnapshotsWithContext - return snapshots from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllSnapshotsWithContext(ctx context.Context, params map[string]string) (*Snapshots, error) {
+	var snapshots Snapshots
+
+	err := cl.IterateSnapshots(ctx, params, func(item Snapshot) bool {
+		snapshots.Items = append(snapshots.Items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots.Metadata = allItemsMetadata(len(snapshots.Items))
+	return &snapshots, nil
+}
+
+// CreateSnapshot - start creation of snapshot, return snapshot creation execution
+func (cl *Client) CreateSnapshot(snapshotID string, options SnapshotPost) (*Execution, error) {
+	return cl.CreateSnapshotWithContext(context.Background(), snapshotID, options)
+}
+
+// CreateSnapshotWithContext - start creation of snapshot, return snapshot
+// creation execution, canceled with context
+func (cl *Client) CreateSnapshotWithContext(ctx context.Context, snapshotID string, options SnapshotPost) (*Execution, error) {
+	var execution ExecutionGet
+
+	err := cl.PutWithContext(ctx, "snapshots/"+snapshotID, options, &execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution.Execution, nil
+}
+
+// CreateSnapshotWait - create snapshot and wait while creation will be finished
+func (cl *Client) CreateSnapshotWait(snapshotID string, options SnapshotPost) (*WaitResult, error) {
+	return cl.CreateSnapshotWaitWithContext(context.Background(), snapshotID, options)
+}
+
+// CreateSnapshotWaitWithContext - create snapshot and wait while creation
+// will be finished, canceled with context
+func (cl *Client) CreateSnapshotWaitWithContext(ctx context.Context, snapshotID string, options SnapshotPost) (*WaitResult, error) {
+	execution, err := cl.CreateSnapshotWithContext(ctx, snapshotID, options)
+	if err != nil {
+		return nil, err
+	}
+	return cl.waitExecution(ctx, cl.executionWaiter(), *execution, true)
+}
+
+// DeleteSnapshot - delete snapshot by id
+func (cl *Client) DeleteSnapshot(snapshotID string) (*SnapshotGet, error) {
+	return cl.DeleteSnapshotWithContext(context.Background(), snapshotID)
+}
+
+// DeleteSnapshotWithContext - delete snapshot by id, canceled with context
+func (cl *Client) DeleteSnapshotWithContext(ctx context.Context, snapshotID string) (*SnapshotGet, error) {
+	var snapshot SnapshotGet
+
+	err := cl.DeleteWithContext(ctx, "snapshots/"+snapshotID, nil, &snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// DownloadSnapshot - download snapshot archive by id to outputPath,
+// <snapshot id>.zip is used if outputPath is empty
+func (cl *Client) DownloadSnapshot(snapshotID, outputPath string) (string, error) {
+	return cl.DownloadSnapshotWithContext(context.Background(), snapshotID, outputPath)
+}
+
+// DownloadSnapshotWithContext - download snapshot archive by id to outputPath,
+// canceled with context
+func (cl *Client) DownloadSnapshotWithContext(ctx context.Context, snapshotID, outputPath string) (string, error) {
+	fileName := outputPath
+	if fileName == "" {
+		fileName = snapshotID + ".zip"
+	}
+
+	_, errFile := os.Stat(fileName)
+	if !os.IsNotExist(errFile) {
+		return "", fmt.Errorf("file `%s` is exist", fileName)
+	}
+
+	err := cl.GetBinaryWithContext(ctx, "snapshots/"+snapshotID+"/archive", fileName)
+	if err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// UploadSnapshot - upload snapshot archive from path as snapshot with id
+func (cl *Client) UploadSnapshot(snapshotID, archivePath string) (*SnapshotGet, error) {
+	return cl.UploadSnapshotWithContext(context.Background(), snapshotID, archivePath)
+}
+
+// UploadSnapshotWithContext - upload snapshot archive from path as snapshot
+// with id, canceled with context
+func (cl *Client) UploadSnapshotWithContext(ctx context.Context, snapshotID, archivePath string) (*SnapshotGet, error) {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	info, err := archive.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot SnapshotGet
+
+	err = cl.PutBinaryStreamWithContext(ctx, "snapshots/"+snapshotID+"/archive", archive, info.Size(), &snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// RestoreSnapshot - start restore of manager from snapshot, return restore execution
+func (cl *Client) RestoreSnapshot(snapshotID string, options SnapshotRestorePost) (*Execution, error) {
+	return cl.RestoreSnapshotWithContext(context.Background(), snapshotID, options)
+}
+
+// RestoreSnapshotWithContext - start restore of manager from snapshot, return
+// restore execution, canceled with context
+func (cl *Client) RestoreSnapshotWithContext(ctx context.Context, snapshotID string, options SnapshotRestorePost) (*Execution, error) {
+	var execution ExecutionGet
+
+	err := cl.PostWithContext(ctx, "snapshots/"+snapshotID+"/restore", options, &execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution.Execution, nil
+}
+
+// RestoreSnapshotWait - restore manager from snapshot and wait while restore
+// execution will be finished
+func (cl *Client) RestoreSnapshotWait(snapshotID string, options SnapshotRestorePost) (*WaitResult, error) {
+	return cl.RestoreSnapshotWaitWithContext(context.Background(), snapshotID, options)
+}
+
+// RestoreSnapshotWaitWithContext - restore manager from snapshot and wait while
+// restore execution will be finished, canceled with context
+func (cl *Client) RestoreSnapshotWaitWithContext(ctx context.Context, snapshotID string, options SnapshotRestorePost) (*WaitResult, error) {
+	execution, err := cl.RestoreSnapshotWithContext(ctx, snapshotID, options)
+	if err != nil {
+		return nil, err
+	}
+	return cl.waitExecution(ctx, cl.executionWaiter(), *execution, true)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const snapshotsResponce = `{
+	"items": [{
+		"status": "created",
+		"tenant_name": "default_tenant",
+		"created_at": "2018-04-02T10:21:05.413Z",
+		"created_by": "admin",
+		"private_resource": false,
+		"visibility": "tenant",
+		"error": "",
+		"id": "backup"
+	}],
+	"metadata": {
+		"pagination": {
+			"total": 1,
+			"offset": 0,
+			"size": 100
+		}
+	}
+}`
+
+// TestGetSnapshots - check GetSnapshots
+func TestGetSnapshots(t *testing.T) {
+	var conn tests.FakeClient
+	conn.GetResponse = []byte(snapshotsResponce)
+	cl := ClientFromConnection(&conn)
+	snapshots, err := cl.GetSnapshots(map[string]string{"id": "backup"})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.GetURL, "snapshots?id=backup",
+		"Recheck url for snapshots: %s", conn.GetURL)
+	tests.AssertEqual(t, snapshots.Items[0].Status, SnapshotCreated,
+		"Recheck unmarshal for 'status' field in snapshot '%s'", snapshots.Items[0].Status)
+}
+
+// TestCreateSnapshot - check that options are sent to snapshot url
+func TestCreateSnapshot(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PutResponse = []byte(executionPendingResponce)
+	cl := ClientFromConnection(&conn)
+	execution, err := cl.CreateSnapshot("backup", SnapshotPost{IncludeLogs: true})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PutURL, "snapshots/backup",
+		"Recheck url for snapshot: %s", conn.PutURL)
+	tests.AssertEqual(t, string(conn.PutData),
+		`{"include_metrics":false,"include_credentials":false,"include_logs":true,"include_events":false,"queue":false}`,
+		"Recheck snapshot options: %s", string(conn.PutData))
+	tests.AssertEqual(t, execution.Status, ExecutionPending,
+		"Recheck execution status: %s", execution.Status)
+}
+
+// TestRestoreSnapshotWait - check that restore waits for execution
+func TestRestoreSnapshotWait(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PostResponse = []byte(executionFailedResponce)
+	cl := ClientFromConnection(&conn)
+	var options SnapshotRestorePost
+	options.Force = true
+	options.Tenant = "old_tenant"
+	result, err := cl.RestoreSnapshotWait("backup", options)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PostURL, "snapshots/backup/restore",
+		"Recheck url for restore: %s", conn.PostURL)
+	tests.AssertEqual(t, string(conn.PostData),
+		`{"force":true,"tenant_name":"old_tenant","restore_certificates":false,"no_reboot":false,"recreate_deployments_envs":false,"ignore_plugin_failure":false}`,
+		"Recheck restore options: %s", string(conn.PostData))
+	tests.AssertEqual(t, result.State, WaitFailed,
+		"Recheck restore state: %s", result.State)
+}
+
+// TestSnapshotArchive - check download and upload of snapshot archive
+func TestSnapshotArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var conn tests.FakeClient
+	conn.GetResponse = []byte("archive content")
+	conn.PutResponse = []byte(`{"id": "backup", "status": "uploaded"}`)
+	cl := ClientFromConnection(&conn)
+
+	archivePath := filepath.Join(dir, "backup.zip")
+	fileName, err := cl.DownloadSnapshot("backup", archivePath)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.GetURL, "snapshots/backup/archive",
+		"Recheck url for download: %s", conn.GetURL)
+	tests.AssertEqual(t, fileName, archivePath, "Recheck file name: %s", fileName)
+
+	if _, err := cl.DownloadSnapshot("backup", archivePath); err == nil {
+		t.Error("Existing file must not be overwritten")
+	}
+
+	snapshot, err := cl.UploadSnapshot("restored", archivePath)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PutURL, "snapshots/restored/archive",
+		"Recheck url for upload: %s", conn.PutURL)
+	tests.AssertEqual(t, string(conn.PutData), "archive content",
+		"Recheck uploaded content: %s", string(conn.PutData))
+	tests.AssertEqual(t, snapshot.Status, SnapshotUploaded,
+		"Recheck snapshot status: %s", snapshot.Status)
+}
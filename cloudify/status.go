@@ -17,6 +17,7 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 )
 
@@ -68,9 +69,14 @@ type Status struct {
 
 // GetVersion - manager version
 func (cl *Client) GetVersion() (*Version, error) {
+	return cl.GetVersionWithContext(context.Background())
+}
+
+// GetVersionWithContext - manager version, canceled with context
+func (cl *Client) GetVersionWithContext(ctx context.Context) (*Version, error) {
 	var ver Version
 
-	err := cl.Get("version", &ver)
+	err := cl.GetWithContext(ctx, "version", &ver)
 	if err != nil {
 		return nil, err
 	}
@@ -80,9 +86,14 @@ func (cl *Client) GetVersion() (*Version, error) {
 
 // GetStatus - manager status
 func (cl *Client) GetStatus() (*Status, error) {
+	return cl.GetStatusWithContext(context.Background())
+}
+
+// GetStatusWithContext - manager status, canceled with context
+func (cl *Client) GetStatusWithContext(ctx context.Context) (*Status, error) {
 	var stat Status
 
-	err := cl.Get("status", &stat)
+	err := cl.GetWithContext(ctx, "status", &stat)
 	if err != nil {
 		return nil, err
 	}
@@ -17,9 +17,18 @@ limitations under the License.
 package cloudify
 
 import (
+	"context"
 	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 )
 
+// Roles of users and user groups in tenant
+const (
+	TenantRoleViewer     = "viewer"
+	TenantRoleUser       = "user"
+	TenantRoleOperations = "operations"
+	TenantRoleManager    = "manager"
+)
+
 // Tenant - information about cloudify tenant
 type Tenant struct {
 	Name   string `json:"name"`
@@ -27,6 +36,26 @@ type Tenant struct {
 	Groups int    `json:"groups"`
 }
 
+// TenantGet - response from manager about selected tenant
+type TenantGet struct {
+	rest.BaseMessage
+	Tenant
+}
+
+// TenantUserPost - information for add user to tenant
+type TenantUserPost struct {
+	TenantName string `json:"tenant_name"`
+	Username   string `json:"username"`
+	Role       string `json:"role,omitempty"`
+}
+
+// TenantUserGroupPost - information for add user group to tenant
+type TenantUserGroupPost struct {
+	TenantName string `json:"tenant_name"`
+	GroupName  string `json:"group_name"`
+	Role       string `json:"role,omitempty"`
+}
+
 // Tenants - cloudify response with tenants list
 type Tenants struct {
 	rest.BaseMessage
@@ -36,14 +65,177 @@ type Tenants struct {
 
 // GetTenants - get tenants list filtered by params
 func (cl *Client) GetTenants(params map[string]string) (*Tenants, error) {
+	return cl.GetTenantsWithContext(context.Background(), params)
+}
+
+// GetTenantsWithContext - get tenants list filtered by params, canceled with context
+func (cl *Client) GetTenantsWithContext(ctx context.Context, params map[string]string) (*Tenants, error) {
 	var tenants Tenants
 
 	values := cl.stringMapToURLValue(params)
 
-	err := cl.Get("tenants?"+values.Encode(), &tenants)
+	err := cl.GetWithContext(ctx, "tenants?"+values.Encode(), &tenants)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenants, nil
+}
+
+// IterateTenants - call handler for each tenant filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateTenants(ctx context.Context, params map[string]string, handler func(Tenant) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		tenants, err := cl.GetTenantsWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range tenants.Items {
+			if !handler(item) {
+				return tenants.Metadata.Pagination, len(tenants.Items), false, nil
+			}
+		}
+		return tenants.Metadata.Pagination, len(tenants.Items), true, nil
+	})
+}
+
+// GetAllTenants - return tenants from all pages filtered by params
+func (cl *Client) GetAllTenants(params map[string]string) (*Tenants, error) {
+	return cl.GetAllTenantsWithContext(context.Background(), params)
+}
+
+// GetAllTenantsWithContext - return tenants from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllTenantsWithContext(ctx context.Context, params map[string]string) (*Tenants, error) {
+	var tenants Tenants
+
+	err := cl.IterateTenants(ctx, params, func(item Tenant) bool {
+		tenants.Items = append(tenants.Items, item)
+		return true
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	tenants.Metadata = allItemsMetadata(len(tenants.Items))
 	return &tenants, nil
 }
+
+// GetTenant - return tenant by name
+func (cl *Client) GetTenant(tenantName string) (*Tenant, error) {
+	return cl.GetTenantWithContext(context.Background(), tenantName)
+}
+
+// GetTenantWithContext - return tenant by name, canceled with context
+func (cl *Client) GetTenantWithContext(ctx context.Context, tenantName string) (*Tenant, error) {
+	var tenant TenantGet
+
+	err := cl.GetWithContext(ctx, "tenants/"+tenantName, &tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Tenant, nil
+}
+
+// CreateTenant - create tenant
+func (cl *Client) CreateTenant(tenantName string) (*Tenant, error) {
+	return cl.CreateTenantWithContext(context.Background(), tenantName)
+}
+
+// CreateTenantWithContext - create tenant, canceled with context
+func (cl *Client) CreateTenantWithContext(ctx context.Context, tenantName string) (*Tenant, error) {
+	var tenant TenantGet
+
+	err := cl.PostWithContext(ctx, "tenants/"+tenantName, struct{}{}, &tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Tenant, nil
+}
+
+// DeleteTenant - delete tenant by name
+func (cl *Client) DeleteTenant(tenantName string) (*Tenant, error) {
+	return cl.DeleteTenantWithContext(context.Background(), tenantName)
+}
+
+// DeleteTenantWithContext - delete tenant by name, canceled with context
+func (cl *Client) DeleteTenantWithContext(ctx context.Context, tenantName string) (*Tenant, error) {
+	var tenant TenantGet
+
+	err := cl.DeleteWithContext(ctx, "tenants/"+tenantName, nil, &tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Tenant, nil
+}
+
+// AddUserToTenant - add user to tenant with role
+func (cl *Client) AddUserToTenant(tenantName, username, role string) (*Tenant, error) {
+	return cl.AddUserToTenantWithContext(context.Background(), tenantName, username, role)
+}
+
+// AddUserToTenantWithContext - add user to tenant with role, canceled with context
+func (cl *Client) AddUserToTenantWithContext(ctx context.Context, tenantName, username, role string) (*Tenant, error) {
+	var tenant TenantGet
+
+	err := cl.PutWithContext(ctx, "tenants/users", TenantUserPost{TenantName: tenantName, Username: username, Role: role}, &tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Tenant, nil
+}
+
+// RemoveUserFromTenant - remove user from tenant
+func (cl *Client) RemoveUserFromTenant(tenantName, username string) (*Tenant, error) {
+	return cl.RemoveUserFromTenantWithContext(context.Background(), tenantName, username)
+}
+
+// RemoveUserFromTenantWithContext - remove user from tenant, canceled with context
+func (cl *Client) RemoveUserFromTenantWithContext(ctx context.Context, tenantName, username string) (*Tenant, error) {
+	var tenant TenantGet
+
+	err := cl.DeleteWithContext(ctx, "tenants/users", TenantUserPost{TenantName: tenantName, Username: username}, &tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Tenant, nil
+}
+
+// AddUserGroupToTenant - add user group to tenant with role
+func (cl *Client) AddUserGroupToTenant(tenantName, groupName, role string) (*Tenant, error) {
+	return cl.AddUserGroupToTenantWithContext(context.Background(), tenantName, groupName, role)
+}
+
+// AddUserGroupToTenantWithContext - add user group to tenant with role, canceled with context
+func (cl *Client) AddUserGroupToTenantWithContext(ctx context.Context, tenantName, groupName, role string) (*Tenant, error) {
+	var tenant TenantGet
+
+	err := cl.PutWithContext(ctx, "tenants/user-groups", TenantUserGroupPost{TenantName: tenantName, GroupName: groupName, Role: role}, &tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Tenant, nil
+}
+
+// RemoveUserGroupFromTenant - remove user group from tenant
+func (cl *Client) RemoveUserGroupFromTenant(tenantName, groupName string) (*Tenant, error) {
+	return cl.RemoveUserGroupFromTenantWithContext(context.Background(), tenantName, groupName)
+}
+
+// RemoveUserGroupFromTenantWithContext - remove user group from tenant, canceled with context
+func (cl *Client) RemoveUserGroupFromTenantWithContext(ctx context.Context, tenantName, groupName string) (*Tenant, error) {
+	var tenant TenantGet
+
+	err := cl.DeleteWithContext(ctx, "tenants/user-groups", TenantUserGroupPost{TenantName: tenantName, GroupName: groupName}, &tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Tenant, nil
+}
@@ -19,6 +19,14 @@ Package tests - fake classes for api testing.
 */
 package tests
 
+import (
+	"bytes"
+	"context"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+	"io"
+	"io/ioutil"
+)
+
 // FakeClient - fake clent for tests
 type FakeClient struct {
 	// get call
@@ -48,8 +56,18 @@ type FakeClient struct {
 	PutResponse []byte
 	PutError    error
 
+	// patch call
+	PatchURL      string
+	PatchType     string
+	PatchData     []byte
+	PatchResponse []byte
+	PatchError    error
+
 	// debug
 	DebugState bool
+
+	// retry
+	RetryPolicy rest.RetryPolicy
 }
 
 // Get - mimic to real get
@@ -83,6 +101,105 @@ func (cl *FakeClient) Put(url, providedContentType string, data []byte) ([]byte,
 	return cl.PutResponse, cl.PutError
 }
 
+// Patch - mimic to real patch
+func (cl *FakeClient) Patch(url, providedContentType string, data []byte) ([]byte, error) {
+	cl.PatchURL = url
+	cl.PatchType = providedContentType
+	cl.PatchData = data
+	return cl.PatchResponse, cl.PatchError
+}
+
+// GetWithContext - mimic to real get, return error if context is already done
+func (cl *FakeClient) GetWithContext(ctx context.Context, url, acceptedContentType string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return cl.Get(url, acceptedContentType)
+}
+
+// DeleteWithContext - mimic to real delete, return error if context is already done
+func (cl *FakeClient) DeleteWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return cl.Delete(url, providedContentType, data)
+}
+
+// PostWithContext - mimic to real post, return error if context is already done
+func (cl *FakeClient) PostWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return cl.Post(url, providedContentType, data)
+}
+
+// PutWithContext - mimic to real put, return error if context is already done
+func (cl *FakeClient) PutWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return cl.Put(url, providedContentType, data)
+}
+
+// PatchWithContext - mimic to real patch, return error if context is already done
+func (cl *FakeClient) PatchWithContext(ctx context.Context, url, providedContentType string, data []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return cl.Patch(url, providedContentType, data)
+}
+
+// GetStream - mimic to real get stream, return get response as stream
+func (cl *FakeClient) GetStream(url, acceptedContentType string) (io.ReadCloser, int64, error) {
+	body, err := cl.Get(url, acceptedContentType)
+	if err != nil {
+		return nil, 0, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(body)), int64(len(body)), nil
+}
+
+// PostStream - mimic to real post stream, stream content is saved as post data
+func (cl *FakeClient) PostStream(url, providedContentType string, input io.Reader, size int64) ([]byte, error) {
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return cl.Post(url, providedContentType, data)
+}
+
+// PutStream - mimic to real put stream, stream content is saved as put data
+func (cl *FakeClient) PutStream(url, providedContentType string, input io.Reader, size int64) ([]byte, error) {
+	data, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return cl.Put(url, providedContentType, data)
+}
+
+// GetStreamWithContext - mimic to real get stream, return error if context is already done
+func (cl *FakeClient) GetStreamWithContext(ctx context.Context, url, acceptedContentType string) (io.ReadCloser, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	return cl.GetStream(url, acceptedContentType)
+}
+
+// PostStreamWithContext - mimic to real post stream, return error if context is already done
+func (cl *FakeClient) PostStreamWithContext(ctx context.Context, url, providedContentType string, input io.Reader, size int64) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return cl.PostStream(url, providedContentType, input, size)
+}
+
+// PutStreamWithContext - mimic to real put stream, return error if context is already done
+func (cl *FakeClient) PutStreamWithContext(ctx context.Context, url, providedContentType string, input io.Reader, size int64) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return cl.PutStream(url, providedContentType, input, size)
+}
+
 // SetDebug - mimic to real set debug
 func (cl *FakeClient) SetDebug(state bool) {
 	cl.DebugState = state
@@ -92,3 +209,8 @@ func (cl *FakeClient) SetDebug(state bool) {
 func (cl *FakeClient) GetDebug() bool {
 	return cl.DebugState
 }
+
+// SetRetryPolicy - mimic to real set retry policy
+func (cl *FakeClient) SetRetryPolicy(policy rest.RetryPolicy) {
+	cl.RetryPolicy = policy
+}
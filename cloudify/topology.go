@@ -0,0 +1,348 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"bytes"
+	"fmt"
+	utils "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/utils"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Base relationship types, all other relationship types are derived from them
+const (
+	RelationshipDependsOn   = "cloudify.relationships.depends_on"
+	RelationshipContainedIn = "cloudify.relationships.contained_in"
+	RelationshipConnectedTo = "cloudify.relationships.connected_to"
+)
+
+// TopologyRelationship - relationship from source to target, source depends
+// on target
+type TopologyRelationship struct {
+	SourceID      string   `json:"source_id"`
+	TargetID      string   `json:"target_id"`
+	Type          string   `json:"type"`
+	TypeHierarchy []string `json:"type_hierarchy,omitempty"`
+}
+
+// IsA - relationship has such type or is derived from such type
+func (rel *TopologyRelationship) IsA(relationshipType string) bool {
+	return rel.Type == relationshipType || utils.InList(rel.TypeHierarchy, relationshipType)
+}
+
+// Topology - in-memory graph of nodes or node instances of one deployment,
+// ids are unique only inside deployment
+type Topology struct {
+	labels       map[string]string
+	dependencies map[string][]TopologyRelationship
+	dependents   map[string][]TopologyRelationship
+}
+
+// NewTopology - empty topology
+func NewTopology() *Topology {
+	return &Topology{
+		labels:       map[string]string{},
+		dependencies: map[string][]TopologyRelationship{},
+		dependents:   map[string][]TopologyRelationship{},
+	}
+}
+
+// NewNodesTopology - topology of deployment nodes
+func NewNodesTopology(nodes []Node) *Topology {
+	topology := NewTopology()
+	for _, node := range nodes {
+		topology.AddVertex(node.ID, node.Type)
+	}
+	for _, node := range nodes {
+		for _, relationship := range node.Relationships {
+			topology.AddRelationship(TopologyRelationship{
+				SourceID:      node.ID,
+				TargetID:      relationship.TargetID,
+				Type:          relationship.Type,
+				TypeHierarchy: relationship.TypeHierarchy,
+			})
+		}
+	}
+	return topology
+}
+
+// NewNodeInstancesTopology - topology of deployment node instances, instance
+// relationships don't have type hierarchy so hierarchy is copied from
+// relationships of nodes with same type, nodes can be nil
+func NewNodeInstancesTopology(instances []NodeInstance, nodes []Node) *Topology {
+	hierarchies := map[string][]string{}
+	for _, node := range nodes {
+		for _, relationship := range node.Relationships {
+			hierarchies[relationship.Type] = relationship.TypeHierarchy
+		}
+	}
+
+	topology := NewTopology()
+	for _, instance := range instances {
+		topology.AddVertex(instance.ID, instance.NodeID)
+	}
+	for _, instance := range instances {
+		for _, relationship := range instance.Relationships {
+			topology.AddRelationship(TopologyRelationship{
+				SourceID:      instance.ID,
+				TargetID:      relationship.TargetID,
+				Type:          relationship.Type,
+				TypeHierarchy: hierarchies[relationship.Type],
+			})
+		}
+	}
+	return topology
+}
+
+// AddVertex - add node or instance to topology, label is used only in DOT
+func (topology *Topology) AddVertex(id, label string) {
+	if _, ok := topology.labels[id]; !ok || label != "" {
+		topology.labels[id] = label
+	}
+}
+
+// AddRelationship - add relationship, source and target are added if they
+// are not in topology
+func (topology *Topology) AddRelationship(rel TopologyRelationship) {
+	topology.AddVertex(rel.SourceID, "")
+	topology.AddVertex(rel.TargetID, "")
+	topology.dependencies[rel.SourceID] = append(topology.dependencies[rel.SourceID], rel)
+	topology.dependents[rel.TargetID] = append(topology.dependents[rel.TargetID], rel)
+}
+
+// IDs - sorted ids of all nodes or instances in topology
+func (topology *Topology) IDs() []string {
+	ids := make([]string, 0, len(topology.labels))
+	for id := range topology.labels {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Relationships - relationships from source to all targets
+func (topology *Topology) Relationships(id string) []TopologyRelationship {
+	return topology.dependencies[id]
+}
+
+// uniqueSorted - sorted list without duplicates
+func uniqueSorted(ids []string) []string {
+	sort.Strings(ids)
+	result := []string{}
+	for pos, id := range ids {
+		if pos == 0 || ids[pos-1] != id {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// Dependencies - sorted ids of direct targets of relationships from id
+func (topology *Topology) Dependencies(id string) []string {
+	ids := []string{}
+	for _, rel := range topology.dependencies[id] {
+		ids = append(ids, rel.TargetID)
+	}
+	return uniqueSorted(ids)
+}
+
+// Dependents - sorted ids of direct sources of relationships to id
+func (topology *Topology) Dependents(id string) []string {
+	ids := []string{}
+	for _, rel := range topology.dependents[id] {
+		ids = append(ids, rel.SourceID)
+	}
+	return uniqueSorted(ids)
+}
+
+// walk - all ids reachable from id by next, without id itself
+func (topology *Topology) walk(id string, next func(id string) []string) []string {
+	visited := map[string]bool{id: true}
+	queue := next(id)
+	ids := []string{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		ids = append(ids, current)
+		queue = append(queue, next(current)...)
+	}
+	return uniqueSorted(ids)
+}
+
+// AllDependencies - sorted ids of all direct and indirect targets of id
+func (topology *Topology) AllDependencies(id string) []string {
+	return topology.walk(id, topology.Dependencies)
+}
+
+// AllDependents - sorted ids of all direct and indirect sources for id,
+// e.g. everything affected by reinstall of id
+func (topology *Topology) AllDependents(id string) []string {
+	return topology.walk(id, topology.Dependents)
+}
+
+// ContainedIn - chain of containers of id, from direct container to root
+// container (usually compute host)
+func (topology *Topology) ContainedIn(id string) []string {
+	chain := []string{}
+	visited := map[string]bool{id: true}
+	for {
+		container := ""
+		for _, rel := range topology.dependencies[id] {
+			if rel.IsA(RelationshipContainedIn) {
+				container = rel.TargetID
+				break
+			}
+		}
+		if container == "" || visited[container] {
+			return chain
+		}
+		visited[container] = true
+		chain = append(chain, container)
+		id = container
+	}
+}
+
+// FindCycle - return ids in cycle like a -> b -> a as [a, b] or nil if
+// topology has no cycles
+func (topology *Topology) FindCycle() []string {
+	const (
+		notVisited = iota
+		inProgress
+		finished
+	)
+	states := map[string]int{}
+	path := []string{}
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		states[id] = inProgress
+		path = append(path, id)
+		for _, target := range topology.Dependencies(id) {
+			switch states[target] {
+			case inProgress:
+				for pos, pathID := range path {
+					if pathID == target {
+						return append([]string{}, path[pos:]...)
+					}
+				}
+			case notVisited:
+				if cycle := visit(target); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		states[id] = finished
+		return nil
+	}
+
+	for _, id := range topology.IDs() {
+		if states[id] == notVisited {
+			if cycle := visit(id); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// InstallOrder - ids sorted so all targets are before sources, ids without
+// dependencies between them are sorted by name. Error is returned if
+// topology has cycle.
+func (topology *Topology) InstallOrder() ([]string, error) {
+	if cycle := topology.FindCycle(); cycle != nil {
+		return nil, fmt.Errorf("Topology has cycle: %s -> %s",
+			strings.Join(cycle, " -> "), cycle[0])
+	}
+
+	waiting := map[string]int{}
+	ready := []string{}
+	for _, id := range topology.IDs() {
+		waiting[id] = len(topology.Dependencies(id))
+		if waiting[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := []string{}
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		current := ready[0]
+		ready = ready[1:]
+		order = append(order, current)
+		for _, source := range topology.Dependents(current) {
+			waiting[source]--
+			if waiting[source] == 0 {
+				ready = append(ready, source)
+			}
+		}
+	}
+	return order, nil
+}
+
+// UninstallOrder - reversed install order, sources are before targets
+func (topology *Topology) UninstallOrder() ([]string, error) {
+	order, err := topology.InstallOrder()
+	if err != nil {
+		return nil, err
+	}
+	for left, right := 0, len(order)-1; left < right; left, right = left+1, right-1 {
+		order[left], order[right] = order[right], order[left]
+	}
+	return order, nil
+}
+
+// shortRelationshipType - last part of relationship type for graph labels
+func shortRelationshipType(relationshipType string) string {
+	return relationshipType[strings.LastIndex(relationshipType, ".")+1:]
+}
+
+// DOT - graph in Graphviz DOT format, contained in relationships are drawn
+// as bold lines and connected to relationships as dashed lines
+func (topology *Topology) DOT(name string) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "digraph %s {\n", strconv.Quote(name))
+	buf.WriteString("\trankdir=BT;\n")
+	buf.WriteString("\tnode [shape=box];\n")
+	for _, id := range topology.IDs() {
+		label := id
+		if topology.labels[id] != "" {
+			label = id + "\n" + topology.labels[id]
+		}
+		fmt.Fprintf(&buf, "\t%s [label=%s];\n", strconv.Quote(id), strconv.Quote(label))
+	}
+	for _, id := range topology.IDs() {
+		for _, rel := range topology.dependencies[id] {
+			attributes := "label=" + strconv.Quote(shortRelationshipType(rel.Type))
+			if rel.IsA(RelationshipContainedIn) {
+				attributes += ", style=bold"
+			} else if rel.IsA(RelationshipConnectedTo) {
+				attributes += ", style=dashed"
+			}
+			fmt.Fprintf(&buf, "\t%s -> %s [%s];\n",
+				strconv.Quote(rel.SourceID), strconv.Quote(rel.TargetID), attributes)
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"encoding/json"
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"strings"
+	"testing"
+)
+
+const topologyNodes = `[{
+	"id": "vm", "deployment_id": "k8s", "type": "cloudify.nodes.Compute"
+}, {
+	"id": "docker", "deployment_id": "k8s", "host_id": "vm",
+	"relationships": [{
+		"type": "cloudify.relationships.contained_in", "target_id": "vm",
+		"type_hierarchy": ["cloudify.relationships.depends_on", "cloudify.relationships.contained_in"]
+	}]
+}, {
+	"id": "db", "deployment_id": "k8s", "host_id": "vm",
+	"relationships": [{
+		"type": "cloudify.relationships.contained_in", "target_id": "vm",
+		"type_hierarchy": ["cloudify.relationships.depends_on", "cloudify.relationships.contained_in"]
+	}]
+}, {
+	"id": "app", "deployment_id": "k8s", "host_id": "vm",
+	"relationships": [{
+		"type": "local.relationships.app_in_docker", "target_id": "docker",
+		"type_hierarchy": ["cloudify.relationships.depends_on", "cloudify.relationships.contained_in", "local.relationships.app_in_docker"]
+	}, {
+		"type": "cloudify.relationships.connected_to", "target_id": "db",
+		"type_hierarchy": ["cloudify.relationships.depends_on", "cloudify.relationships.connected_to"]
+	}]
+}]`
+
+func topologyTestNodes(t *testing.T) []Node {
+	var nodes []Node
+	if err := json.Unmarshal([]byte(topologyNodes), &nodes); err != nil {
+		t.Fatalf("Can't parse nodes: %s", err.Error())
+	}
+	return nodes
+}
+
+// TestTopologyQueries - check dependencies, containers and install order
+func TestTopologyQueries(t *testing.T) {
+	topology := NewNodesTopology(topologyTestNodes(t))
+
+	tests.AssertEqual(t, strings.Join(topology.Dependencies("app"), ","), "db,docker",
+		"Recheck dependencies: %v", topology.Dependencies("app"))
+	tests.AssertEqual(t, strings.Join(topology.Dependents("vm"), ","), "db,docker",
+		"Recheck dependents: %v", topology.Dependents("vm"))
+	tests.AssertEqual(t, strings.Join(topology.AllDependents("vm"), ","), "app,db,docker",
+		"Recheck all dependents: %v", topology.AllDependents("vm"))
+	tests.AssertEqual(t, strings.Join(topology.AllDependencies("app"), ","), "db,docker,vm",
+		"Recheck all dependencies: %v", topology.AllDependencies("app"))
+	tests.AssertEqual(t, strings.Join(topology.ContainedIn("app"), ","), "docker,vm",
+		"Recheck containers: %v", topology.ContainedIn("app"))
+	tests.AssertEqual(t, len(topology.ContainedIn("vm")), 0,
+		"Recheck containers: %v", topology.ContainedIn("vm"))
+
+	if cycle := topology.FindCycle(); cycle != nil {
+		t.Errorf("Topology has no cycles: %v", cycle)
+	}
+	order, err := topology.InstallOrder()
+	tests.AssertEqual(t, strings.Join(order, ","), "vm,db,docker,app",
+		"Recheck install order: %v (%v)", order, err)
+	order, err = topology.UninstallOrder()
+	tests.AssertEqual(t, strings.Join(order, ","), "app,docker,db,vm",
+		"Recheck uninstall order: %v (%v)", order, err)
+
+	topology.AddRelationship(TopologyRelationship{
+		SourceID: "vm", TargetID: "app", Type: RelationshipDependsOn,
+	})
+	tests.AssertEqual(t, strings.Join(topology.FindCycle(), ","), "app,db,vm",
+		"Recheck cycle: %v", topology.FindCycle())
+	if _, err := topology.InstallOrder(); err == nil ||
+		err.Error() != "Topology has cycle: app -> db -> vm -> app" {
+		t.Errorf("Cycle must be reported: %v", err)
+	}
+}
+
+// TestNodeInstancesTopology - check hierarchy of instance relationships
+func TestNodeInstancesTopology(t *testing.T) {
+	var instances []NodeInstance
+	err := json.Unmarshal([]byte(`[{
+		"id": "vm_1", "node_id": "vm"
+	}, {
+		"id": "docker_1", "node_id": "docker",
+		"relationships": [{"type": "cloudify.relationships.contained_in", "target_id": "vm_1"}]
+	}, {
+		"id": "app_1", "node_id": "app",
+		"relationships": [{"type": "local.relationships.app_in_docker", "target_id": "docker_1"}]
+	}]`), &instances)
+	if err != nil {
+		t.Fatalf("Can't parse instances: %s", err.Error())
+	}
+
+	topology := NewNodeInstancesTopology(instances, topologyTestNodes(t))
+	tests.AssertEqual(t, strings.Join(topology.ContainedIn("app_1"), ","), "docker_1,vm_1",
+		"Recheck containers: %v", topology.ContainedIn("app_1"))
+
+	topology = NewNodeInstancesTopology(instances, nil)
+	tests.AssertEqual(t, strings.Join(topology.ContainedIn("app_1"), ","), "",
+		"Custom type is unknown without nodes: %v", topology.ContainedIn("app_1"))
+}
+
+// TestTopologyDOT - check graphviz output
+func TestTopologyDOT(t *testing.T) {
+	topology := NewTopology()
+	topology.AddVertex("vm", "cloudify.nodes.Compute")
+	topology.AddRelationship(TopologyRelationship{
+		SourceID: "app", TargetID: "vm", Type: RelationshipContainedIn,
+	})
+	topology.AddRelationship(TopologyRelationship{
+		SourceID: "app", TargetID: "db", Type: RelationshipConnectedTo,
+	})
+
+	tests.AssertEqual(t, topology.DOT("k8s"), `digraph "k8s" {
+	rankdir=BT;
+	node [shape=box];
+	"app" [label="app"];
+	"db" [label="db"];
+	"vm" [label="vm\ncloudify.nodes.Compute"];
+	"app" -> "vm" [label="contained_in", style=bold];
+	"app" -> "db" [label="connected_to", style=dashed];
+}
+`, "Recheck DOT output: %s", topology.DOT("k8s"))
+}
+
+// TestSelfUpdateGroups - check groups copied from containers
+func TestSelfUpdateGroups(t *testing.T) {
+	var nodes NodeWithGroups
+	for _, node := range topologyTestNodes(t) {
+		nodes.Items = append(nodes.Items, NodeWithGroup{Node: node})
+	}
+	nodes.Items[0].GroupName = "k8s_group"
+	nodes.Items[0].ScalingGroupName = "k8s_scale"
+	nodes.Items[3].GroupName = "app_group"
+	// nodes without relationships in other deployment
+	otherVM := NodeWithGroup{GroupName: "other"}
+	otherVM.ID = "vm"
+	otherVM.DeploymentID = "other"
+	otherVM.HostID = "vm"
+	otherApp := NodeWithGroup{}
+	otherApp.ID = "app"
+	otherApp.DeploymentID = "other"
+	otherApp.HostID = "vm"
+	nodes.Items = append(nodes.Items, otherVM, otherApp)
+
+	nodes.SelfUpdateGroups()
+
+	tests.AssertEqual(t, nodes.Items[1].GroupName, "k8s_group",
+		"Recheck group: %s", nodes.Items[1].GroupName)
+	tests.AssertEqual(t, nodes.Items[3].GroupName, "app_group",
+		"Recheck group: %s", nodes.Items[3].GroupName)
+	tests.AssertEqual(t, nodes.Items[3].ScalingGroupName, "k8s_scale",
+		"Recheck scaling group: %s", nodes.Items[3].ScalingGroupName)
+	tests.AssertEqual(t, nodes.Items[5].GroupName, "other",
+		"Recheck group by host: %s", nodes.Items[5].GroupName)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+)
+
+// UserGroup - information about cloudify user group
+type UserGroup struct {
+	Name    string `json:"name"`
+	LdapDN  string `json:"ldap_dn"`
+	Role    string `json:"role"`
+	Tenants int    `json:"tenants"`
+	Users   int    `json:"users"`
+}
+
+// UserGroupGet - response from manager about selected user group
+type UserGroupGet struct {
+	rest.BaseMessage
+	UserGroup
+}
+
+// UserGroups - cloudify response with user groups list
+type UserGroups struct {
+	rest.BaseMessage
+	Metadata rest.Metadata `json:"metadata"`
+	Items    []UserGroup   `json:"items"`
+}
+
+// UserGroupPost - information for create user group
+type UserGroupPost struct {
+	GroupName   string `json:"group_name"`
+	LdapGroupDN string `json:"ldap_group_dn,omitempty"`
+	Role        string `json:"role,omitempty"`
+}
+
+// UserGroupUserPost - information for add user to group
+type UserGroupUserPost struct {
+	Username  string `json:"username"`
+	GroupName string `json:"group_name"`
+}
+
+// GetUserGroups - return user groups filtered by params
+func (cl *Client) GetUserGroups(params map[string]string) (*UserGroups, error) {
+	return cl.GetUserGroupsWithContext(context.Background(), params)
+}
+
+// GetUserGroupsWithContext - return user groups filtered by params, canceled with context
+func (cl *Client) GetUserGroupsWithContext(ctx context.Context, params map[string]string) (*UserGroups, error) {
+	var groups UserGroups
+
+	values := cl.stringMapToURLValue(params)
+
+	err := cl.GetWithContext(ctx, "user-groups?"+values.Encode(), &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return &groups, nil
+}
+
+// IterateUserGroups - call handler for each user group filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateUserGroups(ctx context.Context, params map[string]string, handler func(UserGroup) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		groups, err := cl.GetUserGroupsWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range groups.Items {
+			if !handler(item) {
+				return groups.Metadata.Pagination, len(groups.Items), false, nil
+			}
+		}
+		return groups.Metadata.Pagination, len(groups.Items), true, nil
+	})
+}
+
+// GetAllUserGroups - return user groups from all pages filtered by params
+func (cl *Client) GetAllUserGroups(params map[string]string) (*UserGroups, error) {
+	return cl.GetAllUserGroupsWithContext(context.Background(), params)
+}
+
+// GetAllUserGroupsWithContext - return user groups from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllUserGroupsWithContext(ctx context.Context, params map[string]string) (*UserGroups, error) {
+	var groups UserGroups
+
+	err := cl.IterateUserGroups(ctx, params, func(item UserGroup) bool {
+		groups.Items = append(groups.Items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups.Metadata = allItemsMetadata(len(groups.Items))
+	return &groups, nil
+}
+
+// GetUserGroup - return user group by name
+func (cl *Client) GetUserGroup(groupName string) (*UserGroup, error) {
+	return cl.GetUserGroupWithContext(context.Background(), groupName)
+}
+
+// GetUserGroupWithContext - return user group by name, canceled with context
+func (cl *Client) GetUserGroupWithContext(ctx context.Context, groupName string) (*UserGroup, error) {
+	var group UserGroupGet
+
+	err := cl.GetWithContext(ctx, "user-groups/"+groupName, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group.UserGroup, nil
+}
+
+// CreateUserGroup - create user group
+func (cl *Client) CreateUserGroup(newGroup UserGroupPost) (*UserGroup, error) {
+	return cl.CreateUserGroupWithContext(context.Background(), newGroup)
+}
+
+// CreateUserGroupWithContext - create user group, canceled with context
+func (cl *Client) CreateUserGroupWithContext(ctx context.Context, newGroup UserGroupPost) (*UserGroup, error) {
+	var group UserGroupGet
+
+	err := cl.PostWithContext(ctx, "user-groups", newGroup, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group.UserGroup, nil
+}
+
+// DeleteUserGroup - delete user group by name
+func (cl *Client) DeleteUserGroup(groupName string) (*UserGroup, error) {
+	return cl.DeleteUserGroupWithContext(context.Background(), groupName)
+}
+
+// DeleteUserGroupWithContext - delete user group by name, canceled with context
+func (cl *Client) DeleteUserGroupWithContext(ctx context.Context, groupName string) (*UserGroup, error) {
+	var group UserGroupGet
+
+	err := cl.DeleteWithContext(ctx, "user-groups/"+groupName, nil, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group.UserGroup, nil
+}
+
+// AddUserToGroup - add user to user group
+func (cl *Client) AddUserToGroup(groupName, username string) (*UserGroup, error) {
+	return cl.AddUserToGroupWithContext(context.Background(), groupName, username)
+}
+
+// AddUserToGroupWithContext - add user to user group, canceled with context
+func (cl *Client) AddUserToGroupWithContext(ctx context.Context, groupName, username string) (*UserGroup, error) {
+	var group UserGroupGet
+
+	err := cl.PutWithContext(ctx, "user-groups/users", UserGroupUserPost{Username: username, GroupName: groupName}, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group.UserGroup, nil
+}
+
+// RemoveUserFromGroup - remove user from user group
+func (cl *Client) RemoveUserFromGroup(groupName, username string) (*UserGroup, error) {
+	return cl.RemoveUserFromGroupWithContext(context.Background(), groupName, username)
+}
+
+// RemoveUserFromGroupWithContext - remove user from user group, canceled with context
+func (cl *Client) RemoveUserFromGroupWithContext(ctx context.Context, groupName, username string) (*UserGroup, error) {
+	var group UserGroupGet
+
+	err := cl.DeleteWithContext(ctx, "user-groups/users", UserGroupUserPost{Username: username, GroupName: groupName}, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group.UserGroup, nil
+}
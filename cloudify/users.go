@@ -0,0 +1,250 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
+)
+
+// System roles of users and user groups
+const (
+	SystemRoleDefault  = "default"
+	SystemRoleSysAdmin = "sys_admin"
+)
+
+// User - information about cloudify user
+type User struct {
+	Username    string `json:"username"`
+	Role        string `json:"role"`
+	Active      bool   `json:"active"`
+	LastLoginAt string `json:"last_login_at"`
+	Tenants     int    `json:"tenants"`
+	Groups      int    `json:"groups"`
+}
+
+// UserGet - response from manager about selected user
+type UserGet struct {
+	rest.BaseMessage
+	User
+}
+
+// Users - cloudify response with users list
+type Users struct {
+	rest.BaseMessage
+	Metadata rest.Metadata `json:"metadata"`
+	Items    []User        `json:"items"`
+}
+
+// UserPost - information for create user
+type UserPost struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     string `json:"role,omitempty"`
+}
+
+// userPassword - request for change user password
+type userPassword struct {
+	Password string `json:"password"`
+}
+
+// userRole - request for change user role
+type userRole struct {
+	Role string `json:"role"`
+}
+
+// userActivation - request for activate/deactivate user
+type userActivation struct {
+	Action string `json:"action"`
+}
+
+// GetUsers - return users filtered by params
+func (cl *Client) GetUsers(params map[string]string) (*Users, error) {
+	return cl.GetUsersWithContext(context.Background(), params)
+}
+
+// GetUsersWithContext - return users filtered by params, canceled with context
+func (cl *Client) GetUsersWithContext(ctx context.Context, params map[string]string) (*Users, error) {
+	var users Users
+
+	values := cl.stringMapToURLValue(params)
+
+	err := cl.GetWithContext(ctx, "users?"+values.Encode(), &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return &users, nil
+}
+
+// IterateUsers - call handler for each user filtered by params,
+// request next pages while handler returns true
+func (cl *Client) IterateUsers(ctx context.Context, params map[string]string, handler func(User) bool) error {
+	return iteratePages(ctx, params, func(ctx context.Context, pageParams map[string]string) (rest.Pagination, int, bool, error) {
+		users, err := cl.GetUsersWithContext(ctx, pageParams)
+		if err != nil {
+			return rest.Pagination{}, 0, false, err
+		}
+		for _, item := range users.Items {
+			if !handler(item) {
+				return users.Metadata.Pagination, len(users.Items), false, nil
+			}
+		}
+		return users.Metadata.Pagination, len(users.Items), true, nil
+	})
+}
+
+// GetAllUsers - return users from all pages filtered by params
+func (cl *Client) GetAllUsers(params map[string]string) (*Users, error) {
+	return cl.GetAllUsersWithContext(context.Background(), params)
+}
+
+// GetAllUsersWithContext - return users from all pages filtered by params,
+// canceled with context
+func (cl *Client) GetAllUsersWithContext(ctx context.Context, params map[string]string) (*Users, error) {
+	var users Users
+
+	err := cl.IterateUsers(ctx, params, func(item User) bool {
+		users.Items = append(users.Items, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users.Metadata = allItemsMetadata(len(users.Items))
+	return &users, nil
+}
+
+// GetUser - return user by name
+func (cl *Client) GetUser(username string) (*User, error) {
+	return cl.GetUserWithContext(context.Background(), username)
+}
+
+// GetUserWithContext - return user by name, canceled with context
+func (cl *Client) GetUserWithContext(ctx context.Context, username string) (*User, error) {
+	var user UserGet
+
+	err := cl.GetWithContext(ctx, "users/"+username, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// CreateUser - create user
+func (cl *Client) CreateUser(newUser UserPost) (*User, error) {
+	return cl.CreateUserWithContext(context.Background(), newUser)
+}
+
+// CreateUserWithContext - create user, canceled with context
+func (cl *Client) CreateUserWithContext(ctx context.Context, newUser UserPost) (*User, error) {
+	var user UserGet
+
+	err := cl.PutWithContext(ctx, "users", newUser, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// DeleteUser - delete user by name
+func (cl *Client) DeleteUser(username string) (*User, error) {
+	return cl.DeleteUserWithContext(context.Background(), username)
+}
+
+// DeleteUserWithContext - delete user by name, canceled with context
+func (cl *Client) DeleteUserWithContext(ctx context.Context, username string) (*User, error) {
+	var user UserGet
+
+	err := cl.DeleteWithContext(ctx, "users/"+username, nil, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// SetUserPassword - change user password
+func (cl *Client) SetUserPassword(username, password string) (*User, error) {
+	return cl.SetUserPasswordWithContext(context.Background(), username, password)
+}
+
+// SetUserPasswordWithContext - change user password, canceled with context
+func (cl *Client) SetUserPasswordWithContext(ctx context.Context, username, password string) (*User, error) {
+	var user UserGet
+
+	err := cl.PostWithContext(ctx, "users/"+username, userPassword{Password: password}, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// SetUserRole - change user system role
+func (cl *Client) SetUserRole(username, role string) (*User, error) {
+	return cl.SetUserRoleWithContext(context.Background(), username, role)
+}
+
+// SetUserRoleWithContext - change user system role, canceled with context
+func (cl *Client) SetUserRoleWithContext(ctx context.Context, username, role string) (*User, error) {
+	var user UserGet
+
+	err := cl.PostWithContext(ctx, "users/"+username, userRole{Role: role}, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// ActivateUser - activate user
+func (cl *Client) ActivateUser(username string) (*User, error) {
+	return cl.ActivateUserWithContext(context.Background(), username)
+}
+
+// ActivateUserWithContext - activate user, canceled with context
+func (cl *Client) ActivateUserWithContext(ctx context.Context, username string) (*User, error) {
+	var user UserGet
+
+	err := cl.PostWithContext(ctx, "users/active/"+username, userActivation{Action: "activate"}, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// DeactivateUser - deactivate user
+func (cl *Client) DeactivateUser(username string) (*User, error) {
+	return cl.DeactivateUserWithContext(context.Background(), username)
+}
+
+// DeactivateUserWithContext - deactivate user, canceled with context
+func (cl *Client) DeactivateUserWithContext(ctx context.Context, username string) (*User, error) {
+	var user UserGet
+
+	err := cl.PostWithContext(ctx, "users/active/"+username, userActivation{Action: "deactivate"}, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"testing"
+)
+
+// TestTenantMembers - check requests for add/remove users and groups in tenant
+func TestTenantMembers(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PutResponse = []byte(`{"name": "team", "users": 1, "groups": 1}`)
+	conn.DeleteResponse = []byte(`{"name": "team", "users": 0, "groups": 1}`)
+	cl := ClientFromConnection(&conn)
+
+	tenant, err := cl.AddUserToTenant("team", "alice", TenantRoleOperations)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PutURL, "tenants/users",
+		"Recheck url for tenant users: %s", conn.PutURL)
+	tests.AssertEqual(t, string(conn.PutData),
+		`{"tenant_name":"team","username":"alice","role":"operations"}`,
+		"Recheck tenant user request: %s", string(conn.PutData))
+	tests.AssertEqual(t, tenant.Users, 1, "Recheck users count: %d", tenant.Users)
+
+	_, err = cl.RemoveUserGroupFromTenant("team", "developers")
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.DeleteURL, "tenants/user-groups",
+		"Recheck url for tenant groups: %s", conn.DeleteURL)
+	tests.AssertEqual(t, string(conn.DeleteData),
+		`{"tenant_name":"team","group_name":"developers"}`,
+		"Recheck tenant group request: %s", string(conn.DeleteData))
+}
+
+// TestCreateUser - check requests for users and user groups
+func TestCreateUser(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PutResponse = []byte(`{"username": "alice", "role": "default", "active": true}`)
+	cl := ClientFromConnection(&conn)
+
+	user, err := cl.CreateUser(UserPost{Username: "alice", Password: "secret", Role: SystemRoleDefault})
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PutURL, "users", "Recheck url for users: %s", conn.PutURL)
+	tests.AssertEqual(t, user.Active, true, "Recheck user state: %v", user.Active)
+
+	conn.PostResponse = []byte(`{"username": "alice", "role": "default", "active": false}`)
+	user, err = cl.DeactivateUser("alice")
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PostURL, "users/active/alice",
+		"Recheck url for user activation: %s", conn.PostURL)
+	tests.AssertEqual(t, string(conn.PostData), `{"action":"deactivate"}`,
+		"Recheck user activation request: %s", string(conn.PostData))
+	tests.AssertEqual(t, user.Active, false, "Recheck user state: %v", user.Active)
+
+	conn.PutResponse = []byte(`{"name": "developers", "users": 1}`)
+	group, err := cl.AddUserToGroup("developers", "alice")
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, conn.PutURL, "user-groups/users",
+		"Recheck url for group users: %s", conn.PutURL)
+	tests.AssertEqual(t, string(conn.PutData), `{"username":"alice","group_name":"developers"}`,
+		"Recheck group user request: %s", string(conn.PutData))
+	tests.AssertEqual(t, group.Users, 1, "Recheck users count: %d", group.Users)
+}
@@ -0,0 +1,220 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Archive formats supported by manager
+const (
+	ArchiveZip    = "zip"
+	ArchiveTar    = "tar"
+	ArchiveTarGz  = "tar.gz"
+	ArchiveTarBz2 = "tar.bz2"
+)
+
+// archiveExtensions - file extensions for each archive format
+var archiveExtensions = map[string][]string{
+	ArchiveZip:    {".zip"},
+	ArchiveTar:    {".tar"},
+	ArchiveTarGz:  {".tar.gz", ".tgz"},
+	ArchiveTarBz2: {".tar.bz2", ".tbz2"},
+}
+
+//ArchiveFormat - return archive format by file name or empty string
+// if file is not archive
+func ArchiveFormat(path string) string {
+	name := strings.ToLower(path)
+	for format, extensions := range archiveExtensions {
+		for _, extension := range extensions {
+			if strings.HasSuffix(name, extension) {
+				return format
+			}
+		}
+	}
+	return ""
+}
+
+//CanCreateArchive - check that archive in format can be created,
+// tar.bz2 archives can be only uploaded as is
+func CanCreateArchive(format string) bool {
+	return format == "" || format == ArchiveZip || format == ArchiveTar || format == ArchiveTarGz
+}
+
+//archiveWriter - common part of writers for each archive format
+type archiveWriter interface {
+	// attach regular file to archive with name
+	attach(name, fullPath string, info os.FileInfo) error
+	Close() error
+}
+
+type zipArchiveWriter struct {
+	writer *zip.Writer
+}
+
+func (w *zipArchiveWriter) attach(name, fullPath string, info os.FileInfo) error {
+	return ZipAttachFile(w.writer, name, fullPath)
+}
+
+func (w *zipArchiveWriter) Close() error {
+	return w.writer.Close()
+}
+
+type tarArchiveWriter struct {
+	writer *tar.Writer
+	// compression writer, closed after archive
+	compressor io.WriteCloser
+}
+
+func (w *tarArchiveWriter) attach(name, fullPath string, info os.FileInfo) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(name)
+	if err := w.writer.WriteHeader(header); err != nil {
+		return err
+	}
+
+	content, err := os.Open(fullPath)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	if _, err := io.Copy(w.writer, content); err != nil {
+		return err
+	}
+	log.Printf("Attached: %s", name)
+	return nil
+}
+
+func (w *tarArchiveWriter) Close() error {
+	err := w.writer.Close()
+	if w.compressor != nil {
+		if errCompress := w.compressor.Close(); err == nil {
+			err = errCompress
+		}
+	}
+	return err
+}
+
+//newArchiveWriter - create writer for archive format
+func newArchiveWriter(output io.Writer, format string) (archiveWriter, error) {
+	switch format {
+	case "", ArchiveZip:
+		return &zipArchiveWriter{writer: zip.NewWriter(output)}, nil
+	case ArchiveTar:
+		return &tarArchiveWriter{writer: tar.NewWriter(output)}, nil
+	case ArchiveTarGz:
+		compressor := gzip.NewWriter(output)
+		return &tarArchiveWriter{writer: tar.NewWriter(compressor), compressor: compressor}, nil
+	}
+	return nil, fmt.Errorf("Unsupported archive format: %s", format)
+}
+
+//IsExcluded - check file name and path relative to archive root by
+// glob patterns like '.git' or '*.pyc'
+func IsExcluded(name string, exclude []string) bool {
+	name = filepath.ToSlash(name)
+	base := filepath.Base(name)
+	for _, pattern := range exclude {
+		if matched, _ := filepath.Match(pattern, base); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+//archiveAttachDir - attach directory content with directory name as root of archive
+func archiveAttachDir(w archiveWriter, currentPath string, exclude []string) error {
+	cleanedup := filepath.Clean(currentPath)
+	dirName := filepath.Dir(cleanedup)
+
+	log.Printf("Looking into %s", currentPath)
+	return filepath.Walk(cleanedup, func(path string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dirName, path)
+		if err != nil {
+			return err
+		}
+		if path != cleanedup && IsExcluded(name, exclude) {
+			if f.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if f.Mode().IsRegular() {
+			return w.attach(name, path, f)
+		}
+		return nil
+	})
+}
+
+//DirArchiveTo - write archive in format from directories and files to
+// writer, files are skipped by exclude patterns
+func DirArchiveTo(output io.Writer, paths []string, format string, exclude []string) error {
+	w, err := newArchiveWriter(output, format)
+	if err != nil {
+		return err
+	}
+
+	for _, currentPath := range paths {
+		info, err := os.Lstat(currentPath)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if err := archiveAttachDir(w, currentPath, exclude); err != nil {
+				return err
+			}
+		} else if info.Mode().IsRegular() {
+			_, file := filepath.Split(currentPath)
+			if err := w.attach(file, currentPath, info); err != nil {
+				return err
+			}
+		}
+	}
+	// Make sure to check the error on Close.
+	return w.Close()
+}
+
+//DirArchiveStream - return archive in format from directories and files
+// as stream, archive is created while stream is read. Close stream for stop
+// archive creation.
+func DirArchiveStream(paths []string, format string, exclude []string) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(DirArchiveTo(writer, paths, format, exclude))
+	}()
+	return reader
+}
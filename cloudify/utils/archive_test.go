@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func ExampleArchiveFormat() {
+	fmt.Println(ArchiveFormat("blueprint.tar.gz"))
+	fmt.Println(ArchiveFormat("blueprint.TBZ2"))
+	fmt.Println(ArchiveFormat("blueprint.yaml") == "")
+	// Output: tar.gz
+	// tar.bz2
+	// true
+}
+
+func TestDirArchiveExclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"blueprint.yaml", "scripts/start.py", "scripts/start.pyc", ".git/HEAD"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := DirArchiveTo(&buf, []string{dir}, ArchiveTarGz, []string{".git", "*.pyc"}); err != nil {
+		t.Errorf("Archive must be created: %s", err.Error())
+		return
+	}
+
+	compressed, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Errorf("Archive must be compressed: %s", err.Error())
+		return
+	}
+	archive := tar.NewReader(compressed)
+	names := []string{}
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Errorf("Archive must be readable: %s", err.Error())
+			return
+		}
+		names = append(names, header.Name)
+	}
+	sort.Strings(names)
+
+	root := filepath.Base(dir)
+	expected := fmt.Sprintf("[%s/blueprint.yaml %s/scripts/start.py]", root, root)
+	if fmt.Sprintf("%v", names) != expected {
+		t.Errorf("Archive must contain %s, got %v", expected, names)
+	}
+
+	if err := DirArchiveTo(&buf, []string{dir}, ArchiveTarBz2, nil); err == nil {
+		t.Error("Unsupported format must be reported")
+	}
+}
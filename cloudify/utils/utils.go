@@ -23,7 +23,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -112,12 +112,13 @@ func ZipAttachFile(w *zip.Writer, zipFileName, fullPath string) error {
 		return errCreate
 	}
 
-	content, errRead := ioutil.ReadFile(fullPath)
-	if errRead != nil {
-		return errRead
+	content, errOpen := os.Open(fullPath)
+	if errOpen != nil {
+		return errOpen
 	}
+	defer content.Close()
 
-	_, errWrite := f.Write(content)
+	_, errWrite := io.Copy(f, content)
 	if errWrite != nil {
 		return errWrite
 	}
@@ -149,36 +150,23 @@ func DirZipArchive(paths []string) ([]byte, error) {
 	// Create a buffer to write our archive to.
 	buf := new(bytes.Buffer)
 
-	// Create a new zip archive.
-	w := zip.NewWriter(buf)
-
-	for _, currentPath := range paths {
-		info, err := os.Lstat(currentPath)
-		if err != nil {
-			return nil, err
-		}
-
-		if info.IsDir() {
-			err := ZipAttachDir(w, currentPath)
-			if err != nil {
-				return nil, err
-			}
-		} else if info.Mode().IsRegular() {
-			_, file := filepath.Split(currentPath)
-			err := ZipAttachFile(w, file, currentPath)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-	// Make sure to check the error on Close.
-	errZip := w.Close()
-	if errZip != nil {
-		return nil, errZip
+	if err := DirZipArchiveTo(buf, paths); err != nil {
+		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
+//DirZipArchiveTo - write archive from directories and files to writer
+func DirZipArchiveTo(output io.Writer, paths []string) error {
+	return DirArchiveTo(output, paths, ArchiveZip, nil)
+}
+
+//DirZipStream - return archive from directories and files as stream, archive
+// is created while stream is read. Close stream for stop archive creation.
+func DirZipStream(paths []string) io.ReadCloser {
+	return DirArchiveStream(paths, ArchiveZip, nil)
+}
+
 //InList - return true if string is already in list
 func InList(source []string, value string) bool {
 	for _, inList := range source {
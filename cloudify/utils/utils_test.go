@@ -17,7 +17,12 @@ limitations under the License.
 package utils
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -95,3 +100,37 @@ func ExamplePrintTable() {
 	// | d | e  | -f- |
 	// +---+----+-----+
 }
+
+func TestDirZipStream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zipstream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "blueprint.yaml"), []byte("node_templates: {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stream := DirZipStream([]string{dir})
+	defer stream.Close()
+	data, err := ioutil.ReadAll(stream)
+	if err != nil {
+		t.Errorf("Archive must be created: %s", err.Error())
+		return
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Errorf("Archive must be readable: %s", err.Error())
+		return
+	}
+	expected := filepath.Base(dir) + "/blueprint.yaml"
+	if len(archive.File) != 1 || archive.File[0].Name != expected {
+		t.Errorf("Archive must contain only %s", expected)
+	}
+
+	if _, err := ioutil.ReadAll(DirZipStream([]string{filepath.Join(dir, "unknown")})); err == nil {
+		t.Error("Stream must return archive error")
+	}
+}
@@ -0,0 +1,374 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Input types supported by validator, other types are not checked
+const (
+	InputTypeString  = "string"
+	InputTypeInteger = "integer"
+	InputTypeFloat   = "float"
+	InputTypeBoolean = "boolean"
+	InputTypeList    = "list"
+	InputTypeDict    = "dict"
+)
+
+// BlueprintValidationError - one problem found in blueprint or inputs
+type BlueprintValidationError struct {
+	// file with problem, empty for problems in inputs
+	File    string
+	Message string
+}
+
+// Error - text representation of error
+func (e BlueprintValidationError) Error() string {
+	if e.File == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.File, e.Message)
+}
+
+// BlueprintValidationErrors - all problems found in blueprint and inputs
+type BlueprintValidationErrors []BlueprintValidationError
+
+// Error - text representation of errors, one problem per line
+func (errs BlueprintValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for pos, err := range errs {
+		messages[pos] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// dslInput - input description in blueprint
+type dslInput struct {
+	Type        string      `yaml:"type"`
+	Default     interface{} `yaml:"default"`
+	Description string      `yaml:"description"`
+	Required    *bool       `yaml:"required"`
+	// default key is set in blueprint, value can be null
+	defaultSet bool
+}
+
+// UnmarshalYAML - decode input and remember that default key is set
+func (input *dslInput) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// alias without custom unmarshal
+	type plainInput dslInput
+
+	var decoded plainInput
+	if err := unmarshal(&decoded); err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+
+	*input = dslInput(decoded)
+	_, input.defaultSet = fields["default"]
+	return nil
+}
+
+// dslDerivedType - node or relationship type description in blueprint
+type dslDerivedType struct {
+	DerivedFrom string `yaml:"derived_from"`
+}
+
+// dslRelationship - relationship of node template in blueprint
+type dslRelationship struct {
+	Type   string `yaml:"type"`
+	Target string `yaml:"target"`
+}
+
+// dslNodeTemplate - node template description in blueprint
+type dslNodeTemplate struct {
+	Type          string            `yaml:"type"`
+	Relationships []dslRelationship `yaml:"relationships"`
+}
+
+// dslBlueprint - parts of blueprint file checked by validator
+type dslBlueprint struct {
+	Imports       []string                   `yaml:"imports"`
+	Inputs        map[string]dslInput        `yaml:"inputs"`
+	NodeTypes     map[string]dslDerivedType  `yaml:"node_types"`
+	Relationships map[string]dslDerivedType  `yaml:"relationships"`
+	NodeTemplates map[string]dslNodeTemplate `yaml:"node_templates"`
+}
+
+// blueprintValidator - state of blueprint validation
+type blueprintValidator struct {
+	errors BlueprintValidationErrors
+	// already loaded files
+	loaded map[string]bool
+	// imports which can't be resolved without network
+	remoteImports []string
+	inputs        map[string]dslInput
+	nodeTypes     map[string]dslDerivedType
+	relationships map[string]dslDerivedType
+	nodeTemplates map[string]dslNodeTemplate
+	// file with node template definition
+	templateFiles map[string]string
+	typeFiles     map[string]string
+}
+
+// addError - save problem found in file
+func (v *blueprintValidator) addError(file, format string, args ...interface{}) {
+	v.errors = append(v.errors, BlueprintValidationError{
+		File:    file,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// isRemoteImport - import can't be resolved offline
+func isRemoteImport(importPath string) bool {
+	return strings.HasPrefix(importPath, "http://") ||
+		strings.HasPrefix(importPath, "https://") ||
+		strings.HasPrefix(importPath, "plugin:")
+}
+
+// loadFile - parse blueprint file and all local imports
+func (v *blueprintValidator) loadFile(path string) {
+	if v.loaded[path] {
+		return
+	}
+	v.loaded[path] = true
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		v.addError(path, "can't read file: %s", err.Error())
+		return
+	}
+
+	var blueprint dslBlueprint
+	if err := yaml.Unmarshal(content, &blueprint); err != nil {
+		v.addError(path, "can't parse file: %s", err.Error())
+		return
+	}
+
+	for name, input := range blueprint.Inputs {
+		v.inputs[name] = input
+	}
+	for name, nodeType := range blueprint.NodeTypes {
+		v.nodeTypes[name] = nodeType
+		v.typeFiles[name] = path
+	}
+	for name, relationship := range blueprint.Relationships {
+		v.relationships[name] = relationship
+		v.typeFiles[name] = path
+	}
+	for name, template := range blueprint.NodeTemplates {
+		v.nodeTemplates[name] = template
+		v.templateFiles[name] = path
+	}
+
+	for _, importPath := range blueprint.Imports {
+		if isRemoteImport(importPath) {
+			v.remoteImports = append(v.remoteImports, importPath)
+			continue
+		}
+		if !filepath.IsAbs(importPath) {
+			importPath = filepath.Join(filepath.Dir(path), importPath)
+		}
+		v.loadFile(importPath)
+	}
+}
+
+// checkType - check that type is defined in blueprint, types from remote
+// imports are unknown so any type is accepted if blueprint has remote imports
+func (v *blueprintValidator) checkType(file, kind, typeName string, known map[string]dslDerivedType) {
+	if typeName == "" {
+		v.addError(file, "%s type is not set", kind)
+		return
+	}
+	if _, ok := known[typeName]; ok {
+		return
+	}
+	if len(v.remoteImports) == 0 {
+		v.addError(file, "%s type %s is not defined", kind, typeName)
+	}
+}
+
+// checkTypes - check node templates, relationships and derived types
+func (v *blueprintValidator) checkTypes() {
+	for _, name := range sortedKeys(v.nodeTypes) {
+		parent := v.nodeTypes[name].DerivedFrom
+		if parent != "" {
+			v.checkType(v.typeFiles[name], "node", parent, v.nodeTypes)
+		}
+	}
+	for _, name := range sortedKeys(v.relationships) {
+		parent := v.relationships[name].DerivedFrom
+		if parent != "" {
+			v.checkType(v.typeFiles[name], "relationship", parent, v.relationships)
+		}
+	}
+
+	templateNames := []string{}
+	for name := range v.nodeTemplates {
+		templateNames = append(templateNames, name)
+	}
+	sort.Strings(templateNames)
+	for _, name := range templateNames {
+		template := v.nodeTemplates[name]
+		file := v.templateFiles[name]
+		v.checkType(file, "node", template.Type, v.nodeTypes)
+		for _, relationship := range template.Relationships {
+			v.checkType(file, "relationship", relationship.Type, v.relationships)
+			if _, ok := v.nodeTemplates[relationship.Target]; !ok {
+				v.addError(file, "node %s has relationship to undefined node %s",
+					name, relationship.Target)
+			}
+		}
+	}
+}
+
+// sortedKeys - names of types in stable order
+func sortedKeys(types map[string]dslDerivedType) []string {
+	names := []string{}
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isFunctionValue - value is intrinsic function like {get_secret: name},
+// resolved by manager
+func isFunctionValue(value interface{}) bool {
+	function, ok := value.(map[string]interface{})
+	if !ok || len(function) != 1 {
+		return false
+	}
+	for name := range function {
+		return strings.HasPrefix(name, "get_") || name == "concat"
+	}
+	return false
+}
+
+// isInputTypeMatch - check value type, value is decoded from json
+func isInputTypeMatch(inputType string, value interface{}) bool {
+	switch inputType {
+	case InputTypeString:
+		_, ok := value.(string)
+		return ok
+	case InputTypeInteger:
+		switch number := value.(type) {
+		case int, int64:
+			return true
+		case float64:
+			return number == math.Trunc(number)
+		}
+		return false
+	case InputTypeFloat:
+		switch value.(type) {
+		case int, int64, float64:
+			return true
+		}
+		return false
+	case InputTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case InputTypeList:
+		_, ok := value.([]interface{})
+		return ok
+	case InputTypeDict:
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+	// custom data types are checked by manager
+	return true
+}
+
+// checkInputs - check that all required inputs are set and have correct types
+func (v *blueprintValidator) checkInputs(inputs map[string]interface{}) {
+	names := []string{}
+	for name := range v.inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		input := v.inputs[name]
+		value, ok := inputs[name]
+		if !ok {
+			if !input.defaultSet && (input.Required == nil || *input.Required) {
+				v.addError("", "required input %s is not set", name)
+			}
+			continue
+		}
+		if isFunctionValue(value) {
+			continue
+		}
+		if !isInputTypeMatch(input.Type, value) {
+			v.addError("", "input %s must be %s, got %v", name, input.Type, value)
+		}
+	}
+
+	unknown := []string{}
+	for name := range inputs {
+		if _, ok := v.inputs[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		v.addError("", "unknown input %s", name)
+	}
+}
+
+// ValidateBlueprint - check blueprint from local path with deployment inputs
+// before upload, returns BlueprintValidationErrors with all found problems.
+// Imports by url or plugin name are not resolved, so node types from such
+// imports are not checked.
+func ValidateBlueprint(blueprintPath string, inputs map[string]interface{}) error {
+	absPath, err := filepath.Abs(blueprintPath)
+	if err != nil {
+		return err
+	}
+
+	v := blueprintValidator{
+		loaded:        map[string]bool{},
+		inputs:        map[string]dslInput{},
+		nodeTypes:     map[string]dslDerivedType{},
+		relationships: map[string]dslDerivedType{},
+		nodeTemplates: map[string]dslNodeTemplate{},
+		templateFiles: map[string]string{},
+		typeFiles:     map[string]string{},
+	}
+	v.loadFile(absPath)
+	if len(v.errors) > 0 {
+		// types and inputs are incomplete without all files
+		return v.errors
+	}
+	v.checkTypes()
+	v.checkInputs(inputs)
+
+	if len(v.errors) > 0 {
+		return v.errors
+	}
+	return nil
+}
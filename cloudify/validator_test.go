@@ -0,0 +1,159 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const validatorBlueprint = `
+tosca_definitions_version: cloudify_dsl_1_3
+imports:
+  - types/types.yaml
+inputs:
+  ip:
+    type: string
+  port:
+    type: integer
+    default: 8080
+  zone:
+    type: string
+    default: null
+  tags:
+    type: list
+    required: false
+node_templates:
+  vm:
+    type: local.nodes.Compute
+  app:
+    type: local.nodes.Application
+    relationships:
+      - type: local.relationships.contained_in
+        target: vm
+`
+
+const validatorTypes = `
+node_types:
+  local.nodes.Root: {}
+  local.nodes.Compute:
+    derived_from: local.nodes.Root
+  local.nodes.Application:
+    derived_from: local.nodes.Root
+relationships:
+  local.relationships.contained_in: {}
+`
+
+// writeValidatorBlueprint - save blueprint files to temporary directory
+func writeValidatorBlueprint(t *testing.T, blueprint, types string) string {
+	dir, err := ioutil.TempDir("", "validator")
+	if err != nil {
+		t.Fatalf("Can't create directory: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "types"), 0755); err != nil {
+		t.Fatalf("Can't create directory: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "blueprint.yaml"), []byte(blueprint), 0644); err != nil {
+		t.Fatalf("Can't write blueprint: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "types", "types.yaml"), []byte(types), 0644); err != nil {
+		t.Fatalf("Can't write types: %v", err)
+	}
+	return dir
+}
+
+// TestValidateBlueprint - check blueprint with correct inputs
+func TestValidateBlueprint(t *testing.T) {
+	dir := writeValidatorBlueprint(t, validatorBlueprint, validatorTypes)
+	defer os.RemoveAll(dir)
+
+	err := ValidateBlueprint(filepath.Join(dir, "blueprint.yaml"), map[string]interface{}{
+		"ip":   "127.0.0.1",
+		"port": float64(80),
+	})
+	if err != nil {
+		t.Errorf("Recheck validation: %v", err)
+	}
+}
+
+// TestValidateBlueprintInputs - check required inputs and types
+func TestValidateBlueprintInputs(t *testing.T) {
+	dir := writeValidatorBlueprint(t, validatorBlueprint, validatorTypes)
+	defer os.RemoveAll(dir)
+
+	err := ValidateBlueprint(filepath.Join(dir, "blueprint.yaml"), map[string]interface{}{
+		"port":    float64(80.5),
+		"unknown": true,
+	})
+	errs, ok := err.(BlueprintValidationErrors)
+	if !ok {
+		t.Errorf("Recheck validation errors: %v", err)
+		return
+	}
+	tests.AssertEqual(t, len(errs), 3, "Recheck validation errors: %v", errs)
+	tests.AssertEqual(t, errs[0].Message, "required input ip is not set",
+		"Recheck required inputs: %v", errs)
+	tests.AssertEqual(t, errs[1].Message, "input port must be integer, got 80.5",
+		"Recheck input types: %v", errs)
+	tests.AssertEqual(t, errs[2].Message, "unknown input unknown",
+		"Recheck unknown inputs: %v", errs)
+
+	// secrets are resolved on manager
+	err = ValidateBlueprint(filepath.Join(dir, "blueprint.yaml"), map[string]interface{}{
+		"ip": map[string]interface{}{"get_secret": "ip"},
+	})
+	if err != nil {
+		t.Errorf("Recheck intrinsic functions in inputs: %v", err)
+	}
+}
+
+// TestValidateBlueprintTypes - check imports and node types
+func TestValidateBlueprintTypes(t *testing.T) {
+	dir := writeValidatorBlueprint(t, validatorBlueprint, `
+node_types:
+  local.nodes.Compute:
+    derived_from: local.nodes.Root
+`)
+	defer os.RemoveAll(dir)
+
+	inputs := map[string]interface{}{"ip": "127.0.0.1"}
+	err := ValidateBlueprint(filepath.Join(dir, "blueprint.yaml"), inputs)
+	errs, ok := err.(BlueprintValidationErrors)
+	if !ok {
+		t.Errorf("Recheck validation errors: %v", err)
+		return
+	}
+	tests.AssertEqual(t, len(errs), 3, "Recheck validation errors: %v", errs)
+	tests.AssertEqual(t, errs[0].Message, "node type local.nodes.Root is not defined",
+		"Recheck derived types: %v", errs)
+	tests.AssertEqual(t, errs[1].Message, "node type local.nodes.Application is not defined",
+		"Recheck node types: %v", errs)
+	tests.AssertEqual(t, errs[2].Message, "relationship type local.relationships.contained_in is not defined",
+		"Recheck relationship types: %v", errs)
+
+	if err := os.Remove(filepath.Join(dir, "types", "types.yaml")); err != nil {
+		t.Fatalf("Can't remove types: %v", err)
+	}
+	err = ValidateBlueprint(filepath.Join(dir, "blueprint.yaml"), inputs)
+	errs, ok = err.(BlueprintValidationErrors)
+	if !ok || len(errs) != 1 {
+		t.Errorf("Recheck unresolved imports: %v", err)
+	}
+}
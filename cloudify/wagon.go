@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// WagonBuildProperties - information about system where wagon was created
+type WagonBuildProperties struct {
+	Distribution        string `json:"distribution"`
+	DistributionVersion string `json:"distribution_version"`
+	DistributionRelease string `json:"distribution_release"`
+}
+
+// WagonMetadata - content of package.json from wagon archive
+type WagonMetadata struct {
+	PackageName             string               `json:"package_name"`
+	PackageVersion          string               `json:"package_version"`
+	PackageSource           string               `json:"package_source"`
+	SupportedPlatform       string               `json:"supported_platform"`
+	SupportedPythonVersions []string             `json:"supported_python_versions"`
+	BuildServerOSProperties WagonBuildProperties `json:"build_server_os_properties"`
+	Wheels                  []string             `json:"wheels"`
+	ExcludedWheels          []string             `json:"excluded_wheels"`
+	ArchiveName             string               `json:"archive_name"`
+	WagonVersion            string               `json:"created_by_wagon_version"`
+}
+
+// pluginYamlDefinition - plugin description from plugin yaml
+type pluginYamlDefinition struct {
+	PackageName    string `yaml:"package_name"`
+	PackageVersion string `yaml:"package_version"`
+}
+
+// InspectWagon - read metadata from local wagon (.wgn) archive
+func InspectWagon(wagonPath string) (*WagonMetadata, error) {
+	file, err := os.Open(wagonPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	compressed, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("file `%s` is not wagon archive: %s", wagonPath, err.Error())
+	}
+	defer compressed.Close()
+
+	archive := tar.NewReader(compressed)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("file `%s` is not wagon archive: %s", wagonPath, err.Error())
+		}
+		// metadata is stored in root directory of archive
+		name := strings.TrimPrefix(path.Clean(header.Name), "./")
+		if path.Base(name) != "package.json" || strings.Count(name, "/") != 1 {
+			continue
+		}
+		content, err := ioutil.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+		var metadata WagonMetadata
+		if err := json.Unmarshal(content, &metadata); err != nil {
+			return nil, fmt.Errorf("can't parse wagon metadata: %s", err.Error())
+		}
+		if metadata.PackageName == "" {
+			return nil, fmt.Errorf("wagon metadata in `%s` has no package name", wagonPath)
+		}
+		return &metadata, nil
+	}
+	return nil, fmt.Errorf("file `%s` has no wagon metadata", wagonPath)
+}
+
+// normalizePackageName - python package names are case insensitive and
+// don't distinguish '-' and '_'
+func normalizePackageName(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "-", -1))
+}
+
+// CheckPluginYaml - check that plugin yaml describes plugin from wagon
+func (metadata *WagonMetadata) CheckPluginYaml(yamlPath string) error {
+	content, err := ioutil.ReadFile(yamlPath)
+	if err != nil {
+		return err
+	}
+
+	var pluginYaml struct {
+		Plugins map[string]pluginYamlDefinition `yaml:"plugins"`
+	}
+	if err := yaml.Unmarshal(content, &pluginYaml); err != nil {
+		return fmt.Errorf("can't parse %s: %s", yamlPath, err.Error())
+	}
+
+	described := []string{}
+	for _, plugin := range pluginYaml.Plugins {
+		if normalizePackageName(plugin.PackageName) != normalizePackageName(metadata.PackageName) {
+			if plugin.PackageName != "" {
+				described = append(described, plugin.PackageName)
+			}
+			continue
+		}
+		if plugin.PackageVersion != "" && plugin.PackageVersion != metadata.PackageVersion {
+			return fmt.Errorf("%s describes %s %s, but wagon contains version %s",
+				yamlPath, plugin.PackageName, plugin.PackageVersion, metadata.PackageVersion)
+		}
+		return nil
+	}
+
+	sort.Strings(described)
+	return fmt.Errorf("%s doesn't describe %s from wagon, described packages: [%s]",
+		yamlPath, metadata.PackageName, strings.Join(described, ", "))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const wagonPackageJSON = `{
+	"package_name": "cloudify-utilities-plugin",
+	"package_version": "1.5.0",
+	"supported_platform": "linux_x86_64",
+	"supported_python_versions": ["py27"],
+	"build_server_os_properties": {
+		"distribution": "centos",
+		"distribution_version": "7.3.1611",
+		"distribution_release": "core"
+	},
+	"wheels": ["cloudify_utilities_plugin-1.5.0-py2-none-any.whl"],
+	"archive_name": "cloudify_utilities_plugin-1.5.0-py27-none-linux_x86_64-centos-Core.wgn",
+	"created_by_wagon_version": "0.6.0"
+}`
+
+// writeTestWagon - create wagon archive with metadata in temporary directory
+func writeTestWagon(t *testing.T, dir string) string {
+	wagonPath := filepath.Join(dir, "plugin.wgn")
+	file, err := os.Create(wagonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	compressed := gzip.NewWriter(file)
+	archive := tar.NewWriter(compressed)
+	files := map[string]string{
+		"cloudify-utilities-plugin/package.json":                                            wagonPackageJSON,
+		"cloudify-utilities-plugin/wheels/cloudify_utilities_plugin-1.5.0-py2-none-any.whl": "wheel",
+	}
+	for name, content := range files {
+		if err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := compressed.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return wagonPath
+}
+
+// TestInspectWagon - check wagon metadata and plugin yaml check
+func TestInspectWagon(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wagon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	metadata, err := InspectWagon(writeTestWagon(t, dir))
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	if metadata.PackageName != "cloudify-utilities-plugin" || metadata.PackageVersion != "1.5.0" {
+		t.Errorf("Recheck wagon package: %s %s", metadata.PackageName, metadata.PackageVersion)
+	}
+	if metadata.SupportedPlatform != "linux_x86_64" || len(metadata.Wheels) != 1 {
+		t.Errorf("Recheck wagon platform and wheels: %+v", metadata)
+	}
+	if metadata.BuildServerOSProperties.Distribution != "centos" {
+		t.Errorf("Recheck wagon distribution: %+v", metadata.BuildServerOSProperties)
+	}
+
+	yamlPath := filepath.Join(dir, "plugin.yaml")
+	checks := map[string]bool{
+		"plugins:\n  utilities:\n    package_name: cloudify_utilities_plugin\n    package_version: '1.5.0'\n": true,
+		"plugins:\n  utilities:\n    package_name: cloudify-utilities-plugin\n":                               true,
+		"plugins:\n  utilities:\n    package_name: cloudify-utilities-plugin\n    package_version: '1.4.0'\n": false,
+		"plugins:\n  vsphere:\n    package_name: cloudify-vsphere-plugin\n":                                   false,
+	}
+	for content, valid := range checks {
+		if err := ioutil.WriteFile(yamlPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		err := metadata.CheckPluginYaml(yamlPath)
+		if (err == nil) != valid {
+			t.Errorf("Recheck plugin yaml check for %s: %v", content, err)
+		}
+	}
+
+	if _, err := InspectWagon(yamlPath); err == nil {
+		t.Error("Non wagon file must be rejected")
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Execution statuses returned by manager
+const (
+	ExecutionPending         = "pending"
+	ExecutionStarted         = "started"
+	ExecutionCancelling      = "cancelling"
+	ExecutionForceCancelling = "force_cancelling"
+	ExecutionTerminated      = "terminated"
+	ExecutionFailed          = "failed"
+	ExecutionCancelled       = "cancelled"
+)
+
+// IsFinishedStatus - execution with such status will never change status again
+func IsFinishedStatus(status string) bool {
+	return status == ExecutionTerminated || status == ExecutionFailed || status == ExecutionCancelled
+}
+
+// IsActiveStatus - execution with such status still runs on manager
+func IsActiveStatus(status string) bool {
+	return status == ExecutionPending || status == ExecutionStarted ||
+		status == ExecutionCancelling || status == ExecutionForceCancelling
+}
+
+// WaitState - final state of waiting for execution
+type WaitState int
+
+// Final states of waiting for execution
+const (
+	// WaitTerminated - execution successfully finished
+	WaitTerminated WaitState = iota
+	// WaitFailed - execution finished with error
+	WaitFailed
+	// WaitCancelled - execution was cancelled
+	WaitCancelled
+	// WaitTimedOut - execution still runs after waiter timeout
+	WaitTimedOut
+	// WaitStarted - execution started, used only without full finish wait
+	WaitStarted
+)
+
+// String - human readable name of state
+func (state WaitState) String() string {
+	switch state {
+	case WaitTerminated:
+		return "terminated"
+	case WaitFailed:
+		return "failed"
+	case WaitCancelled:
+		return "cancelled"
+	case WaitTimedOut:
+		return "timed out"
+	case WaitStarted:
+		return "started"
+	}
+	return fmt.Sprintf("unknown(%d)", int(state))
+}
+
+// WaitResult - execution state after wait
+type WaitResult struct {
+	State     WaitState
+	Execution Execution
+}
+
+// ExecutionWaiter - settings for wait execution status changes
+type ExecutionWaiter struct {
+	// first delay between status checks
+	PollInterval time.Duration
+	// max delay between status checks
+	MaxPollInterval time.Duration
+	// multiplier for delay after each check
+	Backoff float64
+	// overall wait time, zero means wait without limit
+	Timeout time.Duration
+	// called each time when execution status changed,
+	// previous is empty for first call
+	OnStatusChange func(execution Execution, previous string)
+	// called for each new event related to execution
+	OnEvent func(event Event)
+}
+
+// NewExecutionWaiter - waiter with default intervals and without timeout
+func NewExecutionWaiter() *ExecutionWaiter {
+	return &ExecutionWaiter{
+		PollInterval:    time.Second,
+		MaxPollInterval: 15 * time.Second,
+		Backoff:         2,
+	}
+}
+
+// nextInterval - delay before next check
+func (waiter *ExecutionWaiter) nextInterval(interval time.Duration) time.Duration {
+	if waiter.Backoff > 1 {
+		interval = time.Duration(float64(interval) * waiter.Backoff)
+	}
+	if waiter.MaxPollInterval > 0 && interval > waiter.MaxPollInterval {
+		interval = waiter.MaxPollInterval
+	}
+	return interval
+}
+
+// firstInterval - delay before first check
+func (waiter *ExecutionWaiter) firstInterval() time.Duration {
+	if waiter.PollInterval > 0 {
+		return waiter.PollInterval
+	}
+	return time.Second
+}
+
+// waitResultForStatus - convert final execution status to result
+func waitResultForStatus(execution Execution) *WaitResult {
+	result := WaitResult{Execution: execution}
+	switch execution.Status {
+	case ExecutionTerminated:
+		result.State = WaitTerminated
+	case ExecutionFailed:
+		result.State = WaitFailed
+	case ExecutionCancelled:
+		result.State = WaitCancelled
+	default:
+		result.State = WaitStarted
+	}
+	return &result
+}
+
+// SetExecutionWaiter - use custom waiter settings in execution calls
+func (cl *Client) SetExecutionWaiter(waiter *ExecutionWaiter) {
+	cl.waiter = waiter
+}
+
+// executionWaiter - return waiter set by user or default one
+func (cl *Client) executionWaiter() *ExecutionWaiter {
+	if cl.waiter != nil {
+		return cl.waiter
+	}
+	return NewExecutionWaiter()
+}
+
+// reportExecutionEvents - send events created after last check to waiter,
+// return count of already reported events
+func (cl *Client) reportExecutionEvents(ctx context.Context, waiter *ExecutionWaiter, executionID string, offset int) (int, error) {
+	if waiter.OnEvent == nil && !cl.restCl().GetDebug() {
+		return offset, nil
+	}
+
+	var params = map[string]string{}
+	params["execution_id"] = executionID
+	// offset is stable only with fixed order of events
+	params["_sort"] = "@timestamp"
+	params["_offset"] = fmt.Sprintf("%d", offset)
+	err := cl.IterateEvents(ctx, params, func(event Event) bool {
+		offset++
+		if waiter.OnEvent != nil {
+			waiter.OnEvent(event)
+		} else {
+			log.Printf("%s [%s] %s", event.Timestamp, executionID, event.Message)
+		}
+		return true
+	})
+	return offset, err
+}
+
+// reportFinalEvents - report events of finished execution, events are stored
+// asynchronously so last events (like workflow_succeeded) can be saved after
+// status change. Events are checked after poll interval until no new events.
+func (cl *Client) reportFinalEvents(ctx context.Context, waiter *ExecutionWaiter, executionID string, offset int) (int, error) {
+	if waiter.OnEvent == nil && !cl.restCl().GetDebug() {
+		return offset, nil
+	}
+
+	for {
+		if err := sleepWithContext(ctx, waiter.firstInterval()); err != nil {
+			return offset, err
+		}
+		reported, err := cl.reportExecutionEvents(ctx, waiter, executionID, offset)
+		if err != nil || reported == offset {
+			return reported, err
+		}
+		offset = reported
+	}
+}
+
+// WaitExecution - wait while execution will be finished
+func (cl *Client) WaitExecution(executionID string) (*WaitResult, error) {
+	return cl.WaitExecutionWithContext(context.Background(), executionID)
+}
+
+// WaitExecutionWithContext - wait while execution will be finished, canceled with context
+func (cl *Client) WaitExecutionWithContext(ctx context.Context, executionID string) (*WaitResult, error) {
+	execution, err := cl.GetExecutionWithContext(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.waitExecution(ctx, cl.executionWaiter(), *execution, true)
+}
+
+// waitExecution - check execution status until it will be finished or
+// started if fullFinish is false
+func (cl *Client) waitExecution(ctx context.Context, waiter *ExecutionWaiter, execution Execution, fullFinish bool) (*WaitResult, error) {
+	waitCtx := ctx
+	if waiter.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, waiter.Timeout)
+		defer cancel()
+	}
+
+	if waiter.OnStatusChange != nil {
+		waiter.OnStatusChange(execution, "")
+	}
+
+	// report events created before first check
+	eventsOffset, err := cl.reportExecutionEvents(waitCtx, waiter, execution.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := waiter.firstInterval()
+	for !IsFinishedStatus(execution.Status) {
+		if !fullFinish && execution.Status != ExecutionPending {
+			break
+		}
+
+		if cl.restCl().GetDebug() {
+			log.Printf("Check status for %v, last status: %v", execution.ID, execution.Status)
+		}
+
+		err := sleepWithContext(waitCtx, interval)
+		if err == nil {
+			var current *Execution
+			current, err = cl.GetExecutionWithContext(waitCtx, execution.ID)
+			if err == nil {
+				if current.Status != execution.Status && waiter.OnStatusChange != nil {
+					waiter.OnStatusChange(*current, execution.Status)
+				}
+				execution = *current
+				eventsOffset, err = cl.reportExecutionEvents(waitCtx, waiter, execution.ID, eventsOffset)
+			}
+		}
+		if err != nil {
+			// our own timeout, parent context is still alive
+			if waitCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				return &WaitResult{State: WaitTimedOut, Execution: execution}, nil
+			}
+			return nil, err
+		}
+
+		interval = waiter.nextInterval(interval)
+	}
+
+	if IsFinishedStatus(execution.Status) {
+		_, err := cl.reportFinalEvents(waitCtx, waiter, execution.ID, eventsOffset)
+		// execution is finished, our own timeout only stops events report
+		if err != nil && (waitCtx.Err() != context.DeadlineExceeded || ctx.Err() != nil) {
+			return nil, err
+		}
+	}
+	return waitResultForStatus(execution), nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright (c) 2017 GigaSpaces Technologies Ltd. All rights reserved
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	tests "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/tests"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const executionFailedResponce = `{
+	"id": "c7bcf1c5-4dc0-4e5c-8a2e-d4a6b0d9f7a1",
+	"workflow_id": "install",
+	"deployment_id": "deployment",
+	"status": "failed",
+	"error": "Something went wrong"
+}`
+
+const executionPendingResponce = `{
+	"id": "c7bcf1c5-4dc0-4e5c-8a2e-d4a6b0d9f7a1",
+	"workflow_id": "install",
+	"deployment_id": "deployment",
+	"status": "pending"
+}`
+
+const executionStartedResponce = `{
+	"id": "c7bcf1c5-4dc0-4e5c-8a2e-d4a6b0d9f7a1",
+	"workflow_id": "install",
+	"deployment_id": "deployment",
+	"status": "started"
+}`
+
+// TestRunExecutionWaitFinished - check result for already finished execution
+func TestRunExecutionWaitFinished(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PostResponse = []byte(executionFailedResponce)
+	cl := ClientFromConnection(&conn)
+
+	result, err := cl.RunExecutionWait(ExecutionPost{WorkflowID: "install"}, true)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, result.State, WaitFailed,
+		"Recheck wait state: %s", result.State)
+	tests.AssertEqual(t, result.Execution.ErrorMessage, "Something went wrong",
+		"Recheck execution error: %s", result.Execution.ErrorMessage)
+	tests.AssertEqual(t, conn.GetURL, "",
+		"Status must not be requested for finished execution: %s", conn.GetURL)
+}
+
+// TestRunExecutionWaitTimeout - check that waiter reports timeout and status changes
+func TestRunExecutionWaitTimeout(t *testing.T) {
+	var conn tests.FakeClient
+	conn.PostResponse = []byte(executionPendingResponce)
+	conn.GetResponse = []byte(executionStartedResponce)
+	cl := ClientFromConnection(&conn)
+
+	var statuses []string
+	waiter := NewExecutionWaiter()
+	waiter.PollInterval = 5 * time.Millisecond
+	waiter.MaxPollInterval = 10 * time.Millisecond
+	waiter.Timeout = 50 * time.Millisecond
+	waiter.OnStatusChange = func(execution Execution, previous string) {
+		statuses = append(statuses, previous+"->"+execution.Status)
+	}
+	cl.SetExecutionWaiter(waiter)
+
+	result, err := cl.RunExecutionWait(ExecutionPost{WorkflowID: "install"}, true)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, result.State, WaitTimedOut,
+		"Recheck wait state: %s", result.State)
+	tests.AssertEqual(t, len(statuses), 2, "Recheck status changes: %v", statuses)
+	tests.AssertEqual(t, statuses[1], "pending->started",
+		"Recheck status changes: %v", statuses)
+
+	_, err = cl.RunExecution(ExecutionPost{WorkflowID: "install"}, true)
+	if err == nil {
+		t.Error("Recheck timeout error reporting")
+	}
+
+	// without full finish we wait only for start
+	result, err = cl.RunExecutionWait(ExecutionPost{WorkflowID: "install"}, false)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, result.State, WaitStarted,
+		"Recheck wait state: %s", result.State)
+}
+
+// lateEventsClient - fake client which finishes execution on first status
+// check and stores last event after events request
+type lateEventsClient struct {
+	tests.FakeClient
+	finished   bool
+	stored     []Event
+	late       []Event
+	eventsURLs []string
+}
+
+func (cl *lateEventsClient) GetWithContext(ctx context.Context, path, acceptedContentType string) ([]byte, error) {
+	if !strings.HasPrefix(path, "events?") {
+		cl.finished = true
+		cl.GetResponse = []byte(strings.Replace(executionStartedResponce, "started", "terminated", 1))
+		return cl.FakeClient.GetWithContext(ctx, path, acceptedContentType)
+	}
+
+	cl.eventsURLs = append(cl.eventsURLs, path)
+	query, err := url.ParseQuery(strings.TrimPrefix(path, "events?"))
+	if err != nil {
+		return nil, err
+	}
+	offset, err := strconv.Atoi(query.Get("_offset"))
+	if err != nil {
+		return nil, err
+	}
+
+	var events Events
+	if offset < len(cl.stored) {
+		events.Items = cl.stored[offset:]
+	}
+	events.Metadata.Pagination.Total = uint(len(cl.stored))
+	events.Metadata.Pagination.Offset = uint(offset)
+	events.Metadata.Pagination.Size = uint(len(events.Items))
+	if cl.finished {
+		// event is saved after status change and after first request
+		cl.stored = append(cl.stored, cl.late...)
+		cl.late = nil
+	}
+	cl.GetResponse, err = json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+	return cl.FakeClient.GetWithContext(ctx, path, acceptedContentType)
+}
+
+// TestWaitExecutionLateEvents - check that events saved after execution
+// finish are reported
+func TestWaitExecutionLateEvents(t *testing.T) {
+	conn := lateEventsClient{
+		stored: []Event{{Message: "Starting 'install' workflow execution"}},
+		late:   []Event{{Message: "'install' workflow execution succeeded"}},
+	}
+	conn.PostResponse = []byte(executionStartedResponce)
+	cl := ClientFromConnection(&conn)
+
+	var messages []string
+	waiter := NewExecutionWaiter()
+	waiter.PollInterval = time.Millisecond
+	waiter.OnEvent = func(event Event) {
+		messages = append(messages, event.Message)
+	}
+	cl.SetExecutionWaiter(waiter)
+
+	result, err := cl.RunExecutionWait(ExecutionPost{WorkflowID: "install"}, true)
+	if err != nil {
+		t.Errorf("Recheck error reporting: %v", err)
+		return
+	}
+	tests.AssertEqual(t, result.State, WaitTerminated,
+		"Recheck wait state: %s", result.State)
+	tests.AssertEqual(t, fmt.Sprintf("%v", messages),
+		"[Starting 'install' workflow execution 'install' workflow execution succeeded]",
+		"Recheck reported events: %v", messages)
+	for _, eventsURL := range conn.eventsURLs {
+		if !strings.Contains(eventsURL, "_sort=%40timestamp") {
+			t.Errorf("Events must be sorted by timestamp: %s", eventsURL)
+		}
+	}
+}
+
+// TestExecutionWaiterInterval - check backoff between status checks
+func TestExecutionWaiterInterval(t *testing.T) {
+	waiter := NewExecutionWaiter()
+	interval := waiter.firstInterval()
+	tests.AssertEqual(t, interval, time.Second, "Recheck first interval: %v", interval)
+	interval = waiter.nextInterval(interval)
+	tests.AssertEqual(t, interval, 2*time.Second, "Recheck backoff: %v", interval)
+	interval = waiter.nextInterval(10 * time.Second)
+	tests.AssertEqual(t, interval, 15*time.Second, "Recheck max interval: %v", interval)
+}
@@ -22,9 +22,12 @@ mount, unmount calls.
 package kubernetes
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	cloudify "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify"
+	rest "github.com/cloudify-incubator/cloudify-rest-go-client/cloudify/rest"
 	"log"
 )
 
@@ -59,7 +62,9 @@ func runAction(cl *cloudify.Client, action string, params map[string]interface{}
 	exec.Parameters["allow_kwargs_override"] = nil
 	exec.Parameters["node_instance_ids"] = []string{instance}
 	exec.Parameters["operation_kwargs"] = params
-	execution, err := cl.RunExecution(exec, true)
+	// operations are safe for repeat, so post can be retried on manager restart
+	execution, err := cl.RunExecutionWithContext(
+		rest.AllowRetry(context.Background()), exec, true)
 	if err != nil {
 		return err
 	}
@@ -67,7 +72,7 @@ func runAction(cl *cloudify.Client, action string, params map[string]interface{}
 	log.Printf("Final status for %v, last status: %v", execution.ID, execution.Status)
 
 	if execution.Status == "failed" {
-		return fmt.Errorf(execution.ErrorMessage)
+		return errors.New(execution.ErrorMessage)
 	}
 	return nil
 }